@@ -2,16 +2,43 @@ package main
 
 import (
 	"container/list"
+	"context"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
-// DirCache is an LRU cache for directory children.
-// Thread-safe for concurrent access.
+// negTTL is how long a confirmed-empty directory stays in the negative
+// cache. Short-lived because, unlike a positive hit, a negative result
+// short-circuits a full DB round trip on every repeat lookup, so a stale
+// one is cheap to let expire and re-check.
+const negTTL = 5 * time.Second
+
+// prefetchConcurrency bounds how many prefetch fetches run at once, so a
+// single directory open doesn't flood DuckDB with speculative queries.
+const prefetchConcurrency = 4
+
+// DirCache is an LRU cache for directory children, plus a short-TTL
+// negative cache for directories confirmed to have none, and a bounded
+// speculative prefetch worker. Thread-safe for concurrent access.
 type DirCache struct {
 	capacity int
 	items    map[string]*list.Element
 	order    *list.List
 	mu       sync.RWMutex
+
+	negMu    sync.Mutex
+	negative map[string]time.Time // path -> expiry
+
+	sf          singleflight.Group
+	prefetchSem chan struct{}
+	generation  int64 // bumped by CancelPrefetches to abandon stale prefetch batches
+
+	hits       int64
+	misses     int64
+	prefetches int64
 }
 
 type cacheEntry struct {
@@ -22,9 +49,11 @@ type cacheEntry struct {
 // NewDirCache creates a new LRU cache with the given capacity.
 func NewDirCache(capacity int) *DirCache {
 	return &DirCache{
-		capacity: capacity,
-		items:    make(map[string]*list.Element),
-		order:    list.New(),
+		capacity:    capacity,
+		items:       make(map[string]*list.Element),
+		order:       list.New(),
+		negative:    make(map[string]time.Time),
+		prefetchSem: make(chan struct{}, prefetchConcurrency),
 	}
 }
 
@@ -32,13 +61,44 @@ func NewDirCache(capacity int) *DirCache {
 // Returns nil if not found. Moves accessed item to front.
 func (c *DirCache) Get(path string) []*Node {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	elem, ok := c.items[path]
+	if !ok {
+		c.mu.Unlock()
+		atomic.AddInt64(&c.misses, 1)
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	children := elem.Value.(*cacheEntry).children
+	c.mu.Unlock()
 
-	if elem, ok := c.items[path]; ok {
-		c.order.MoveToFront(elem)
-		return elem.Value.(*cacheEntry).children
+	atomic.AddInt64(&c.hits, 1)
+	return children
+}
+
+// Negative reports whether path is known, within its TTL, to have zero
+// children, letting callers skip a DB round trip for files and empty
+// directories that Get can't otherwise distinguish from a plain cache miss
+// (both look like a nil slice).
+func (c *DirCache) Negative(path string) bool {
+	c.negMu.Lock()
+	defer c.negMu.Unlock()
+
+	expiry, ok := c.negative[path]
+	if !ok {
+		return false
 	}
-	return nil
+	if time.Now().After(expiry) {
+		delete(c.negative, path)
+		return false
+	}
+	return true
+}
+
+// SetNegative marks path as having zero children for negTTL.
+func (c *DirCache) SetNegative(path string) {
+	c.negMu.Lock()
+	defer c.negMu.Unlock()
+	c.negative[path] = time.Now().Add(negTTL)
 }
 
 // Set stores children for a path in the cache.
@@ -72,13 +132,16 @@ func (c *DirCache) Set(path string, children []*Node) {
 	c.items[path] = elem
 }
 
-// Clear removes all entries from the cache.
+// Clear removes all entries from the cache, positive and negative.
 func (c *DirCache) Clear() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	c.items = make(map[string]*list.Element)
 	c.order = list.New()
+	c.mu.Unlock()
+
+	c.negMu.Lock()
+	c.negative = make(map[string]time.Time)
+	c.negMu.Unlock()
 }
 
 // Len returns the current number of cached directories.
@@ -106,3 +169,78 @@ func (c *DirCache) Invalidate(path string) {
 		delete(c.items, path)
 	}
 }
+
+// CacheStats holds hit/miss/prefetch counters for the stats bar.
+type CacheStats struct {
+	Hits       int64
+	Misses     int64
+	Prefetches int64
+}
+
+// Stats returns a snapshot of the cache's hit/miss/prefetch counters.
+func (c *DirCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:       atomic.LoadInt64(&c.hits),
+		Misses:     atomic.LoadInt64(&c.misses),
+		Prefetches: atomic.LoadInt64(&c.prefetches),
+	}
+}
+
+// CancelPrefetches abandons any in-flight PrefetchChildren batch. Callers
+// invoke this when the user navigates away from the directory a prefetch
+// was started for, so fetches for paths the user is no longer near don't
+// keep occupying the concurrency-limited worker pool.
+func (c *DirCache) CancelPrefetches() {
+	atomic.AddInt64(&c.generation, 1)
+}
+
+// PrefetchChildren speculatively warms the cache for paths via fetcher,
+// meant to be called with a directory's largest subdirectories right
+// after the user opens it so descending into one doesn't pay DuckDB's
+// query latency. In-flight requests for the same path are deduped via a
+// singleflight.Group, concurrency is capped at prefetchConcurrency, and
+// the whole batch aborts early if ctx is canceled or CancelPrefetches is
+// called for a newer batch while this one is still running.
+func (c *DirCache) PrefetchChildren(ctx context.Context, paths []string, fetcher func(string) ([]*Node, error)) {
+	gen := atomic.LoadInt64(&c.generation)
+
+	var wg sync.WaitGroup
+	for _, path := range paths {
+		if c.Contains(path) || c.Negative(path) {
+			continue
+		}
+
+		path := path
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case c.prefetchSem <- struct{}{}:
+				defer func() { <-c.prefetchSem }()
+			case <-ctx.Done():
+				return
+			}
+
+			if ctx.Err() != nil || atomic.LoadInt64(&c.generation) != gen {
+				return
+			}
+
+			v, err, _ := c.sf.Do(path, func() (any, error) {
+				return fetcher(path)
+			})
+			if err != nil {
+				return
+			}
+			atomic.AddInt64(&c.prefetches, 1)
+
+			children, _ := v.([]*Node)
+			if len(children) == 0 {
+				c.SetNegative(path)
+				return
+			}
+			c.Set(path, children)
+		}()
+	}
+	wg.Wait()
+}
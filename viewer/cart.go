@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleCart adds or removes the selected node from the cart, keyed by
+// FullPath so the same node toggles off if selected again from a
+// different view (e.g. marked in ViewFlat, unmarked from ViewTree).
+func (m *model) toggleCart() tea.Cmd {
+	selected := m.getSelected()
+	if selected == nil {
+		return m.setStatus("Nothing selected to mark")
+	}
+
+	if m.cart == nil {
+		m.cart = make(map[string]*Node)
+	}
+
+	if _, marked := m.cart[selected.FullPath]; marked {
+		delete(m.cart, selected.FullPath)
+		return m.setStatus("Unmarked: " + selected.FullPath)
+	}
+
+	m.cart[selected.FullPath] = selected
+	return m.setStatus(fmt.Sprintf("Marked (%d in cart): %s", len(m.cart), selected.FullPath))
+}
+
+// cartList returns the cart's nodes sorted by usage descending, so
+// ViewCart's rows and getSelected agree on order.
+func (m model) cartList() []*Node {
+	list := make([]*Node, 0, len(m.cart))
+	for _, n := range m.cart {
+		list = append(list, n)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Usage > list[j].Usage
+	})
+	return list
+}
+
+// cartTotal returns the total bytes reclaimable across the cart.
+func (m model) cartTotal() int64 {
+	var total int64
+	for _, n := range m.cart {
+		total += n.Usage
+	}
+	return total
+}
+
+// cartExportFormat selects how exportCart renders the cart to disk.
+type cartExportFormat int
+
+const (
+	cartExportNullList cartExportFormat = iota // null-delimited path list, for xargs -0
+	cartExportScript                           // POSIX rm -rf shell script
+	cartExportManifest                         // JSON manifest
+)
+
+// cartExportFormatFor picks an export format from the output path's
+// extension: .sh for a shell script, .json for a manifest, anything else
+// for a plain null-delimited list.
+func cartExportFormatFor(path string) cartExportFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sh":
+		return cartExportScript
+	case ".json":
+		return cartExportManifest
+	default:
+		return cartExportNullList
+	}
+}
+
+// exportCart writes the cart to path in the format its extension selects.
+// The parquet is only a point-in-time snapshot, so every entry in the
+// script format carries a `[ -e path ] || exit` guard: if a path the
+// audit saw no longer exists, the script stops there. The guard checks
+// existence only. The manifest and null-delimited formats carry no guard.
+func (m *model) exportCart(path string) tea.Cmd {
+	list := m.cartList()
+	if len(list) == 0 {
+		return m.setStatus("Cart is empty, nothing to export")
+	}
+
+	var err error
+	switch cartExportFormatFor(path) {
+	case cartExportScript:
+		err = writeCartScript(path, list)
+	case cartExportManifest:
+		err = writeCartManifest(path, list)
+	default:
+		err = writeCartNullList(path, list)
+	}
+
+	if err != nil {
+		return m.setStatus(fmt.Sprintf("Export failed: %v", err))
+	}
+	return m.setStatus(fmt.Sprintf("Exported %d entries to %s", len(list), path))
+}
+
+// writeCartScript writes list as a POSIX rm -rf script, annotated with the
+// snapshot's export timestamp (the parquet schema itself carries no
+// capture timestamp to use instead) and a human-readable size per entry.
+func writeCartScript(path string, list []*Node) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#!/bin/sh\n# gdu-view cart export, written %s\n# Review before running: this deletes real files.\n\n",
+		time.Now().Format(time.RFC3339))
+
+	for _, n := range list {
+		quoted := shellQuote(n.FullPath)
+		fmt.Fprintf(&b, "# %s (%s)\n", n.Name, humanize(n.Usage))
+		fmt.Fprintf(&b, "[ -e %s ] || exit\n", quoted)
+		fmt.Fprintf(&b, "rm -rf -- %s\n\n", quoted)
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0755)
+}
+
+// shellQuote wraps s in single quotes for safe use as one word in a POSIX
+// shell command, escaping any embedded single quotes as '\''. Go's %q is
+// not a substitute here: it escapes Go-string-special characters, not
+// shell ones, so a name containing $( ) or a backtick would still be
+// live inside a double-quoted shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// writeCartNullList writes list as NUL-delimited paths, for `xargs -0`.
+func writeCartNullList(path string, list []*Node) error {
+	var b strings.Builder
+	for _, n := range list {
+		b.WriteString(n.FullPath)
+		b.WriteByte(0)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+type cartManifestEntry struct {
+	Path  string `json:"path"`
+	Usage int64  `json:"usage"`
+	IsDir bool   `json:"is_dir"`
+}
+
+type cartManifest struct {
+	ExportedAt time.Time           `json:"exported_at"`
+	Entries    []cartManifestEntry `json:"entries"`
+}
+
+// writeCartManifest writes list as a JSON manifest.
+func writeCartManifest(path string, list []*Node) error {
+	manifest := cartManifest{ExportedAt: time.Now()}
+	for _, n := range list {
+		manifest.Entries = append(manifest.Entries, cartManifestEntry{
+			Path:  n.FullPath,
+			Usage: n.Usage,
+			IsDir: n.IsDir,
+		})
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(manifest)
+}
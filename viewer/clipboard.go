@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// currentList returns the node list backing the active view (after
+// filtering), matching the precedence getSelected uses. Returns nil for
+// ViewTypes, which has no *Node list.
+func (m model) currentList() []*Node {
+	switch m.viewMode {
+	case ViewTree:
+		if m.filtered != nil {
+			return m.filtered
+		}
+		return m.children
+	case ViewFlat:
+		if m.filtered != nil {
+			return m.filtered
+		}
+		return m.flatList
+	case ViewLargest:
+		if m.filtered != nil {
+			return m.filtered
+		}
+		return m.largestFiles
+	}
+	return nil
+}
+
+// yankSelectedPath copies the selected node's full path to the system
+// clipboard.
+func (m *model) yankSelectedPath() tea.Cmd {
+	selected := m.getSelected()
+	if selected == nil {
+		return m.setStatus("Nothing selected to yank")
+	}
+	if err := clipboard.WriteAll(selected.FullPath); err != nil {
+		return m.setStatus(fmt.Sprintf("Clipboard unavailable: %v", err))
+	}
+	return m.setStatus("Yanked path: " + selected.FullPath)
+}
+
+// yankCurrentListing copies the current view's listing to the clipboard as
+// TSV of name, size, and type.
+func (m *model) yankCurrentListing() tea.Cmd {
+	list := m.currentList()
+	if len(list) == 0 {
+		return m.setStatus("Nothing to yank")
+	}
+
+	var b strings.Builder
+	b.WriteString("name\tsize\ttype\n")
+	for _, n := range list {
+		fmt.Fprintf(&b, "%s\t%d\t%s\n", n.Name, n.Usage, n.FileType)
+	}
+
+	if err := clipboard.WriteAll(b.String()); err != nil {
+		return m.setStatus(fmt.Sprintf("Clipboard unavailable: %v", err))
+	}
+	return m.setStatus(fmt.Sprintf("Yanked %d rows as TSV", len(list)))
+}
+
+// exportCurrentView writes the current view's data to stdout as JSON and
+// quits the program, for piping the active view into other tools.
+func (m *model) exportCurrentView() tea.Cmd {
+	var data any = m.currentList()
+	if m.viewMode == ViewTypes {
+		data = m.typeStats
+	}
+
+	return tea.Sequence(
+		func() tea.Msg {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(data)
+			return nil
+		},
+		tea.Quit,
+	)
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	goyaml "github.com/goccy/go-yaml"
+)
+
+// defaultSampleSize is how much of a file --sample-compress reads when no
+// --compress-sample-size is given: the first N bytes, or the whole file
+// when it's smaller than that.
+const defaultSampleSize = 256 * 1024 // 256 KiB
+
+// defaultCompressWorkers bounds the worker pool's default size when no
+// --compress-workers is given.
+const defaultCompressWorkers = 8
+
+// compressSkipCategories are file categories DetectFileType already
+// recognizes as compressed or inherently high-entropy; sampling them
+// would just burn CPU re-compressing bytes that won't shrink further.
+var compressSkipCategories = map[string]bool{
+	"archive": true,
+	"media":   true,
+}
+
+// CompressibilityEstimate is one codec's sampled result: the ratio of
+// compressed to original sample size (smaller means more compressible)
+// and the estimated compressed size if the file's full usage shrank at
+// that same ratio.
+type CompressibilityEstimate struct {
+	Ratio    float32
+	Estimate int64
+}
+
+// EstimateCompressibility samples up to sampleSize bytes from path (or the
+// whole file, whichever is smaller) and compresses the sample in memory
+// under both gzip and zstd, returning an estimate for each so callers can
+// compare what either codec would reclaim.
+func EstimateCompressibility(path string, usage, sampleSize int64) (gz, zs CompressibilityEstimate, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return CompressibilityEstimate{}, CompressibilityEstimate{}, err
+	}
+	defer f.Close()
+
+	limit := sampleSize
+	if usage > 0 && usage < limit {
+		limit = usage
+	}
+
+	sample, err := io.ReadAll(io.LimitReader(f, limit))
+	if err != nil {
+		return CompressibilityEstimate{}, CompressibilityEstimate{}, err
+	}
+	if len(sample) == 0 {
+		full := CompressibilityEstimate{Ratio: 1, Estimate: usage}
+		return full, full, nil
+	}
+
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	if _, err := gw.Write(sample); err != nil {
+		return CompressibilityEstimate{}, CompressibilityEstimate{}, err
+	}
+	if err := gw.Close(); err != nil {
+		return CompressibilityEstimate{}, CompressibilityEstimate{}, err
+	}
+
+	zw, err := zstd.NewWriter(nil)
+	if err != nil {
+		return CompressibilityEstimate{}, CompressibilityEstimate{}, err
+	}
+	zstdBytes := zw.EncodeAll(sample, nil)
+	if err := zw.Close(); err != nil {
+		return CompressibilityEstimate{}, CompressibilityEstimate{}, err
+	}
+
+	gz.Ratio = float32(gzBuf.Len()) / float32(len(sample))
+	gz.Estimate = int64(float32(usage) * gz.Ratio)
+	zs.Ratio = float32(len(zstdBytes)) / float32(len(sample))
+	zs.Estimate = int64(float32(usage) * zs.Ratio)
+	return gz, zs, nil
+}
+
+// sampleCompressNode sets node.Compressibility/CompressedEstimate and
+// node.ZstdCompressibility/ZstdCompressedEstimate in place, skipping
+// directories and anything whose FileType or sniffed magic bytes mark it
+// as compressed or media. Errors reading a file (permissions, since-deleted paths) are
+// swallowed the same way DeepScanNode swallows sniff failures: the node is
+// simply left unestimated.
+func sampleCompressNode(node *Node, sampleSize int64) {
+	if node.IsDir || compressSkipCategories[node.FileType] {
+		return
+	}
+	if header, err := sniffHeader(node.FullPath); err == nil {
+		if category, _, _ := DetectFileType(node.FullPath, header); compressSkipCategories[category] {
+			return
+		}
+	}
+
+	gz, zs, err := EstimateCompressibility(node.FullPath, node.Usage, sampleSize)
+	if err != nil {
+		return
+	}
+
+	node.Compressibility = gz.Ratio
+	node.CompressedEstimate = gz.Estimate
+	node.ZstdCompressibility = zs.Ratio
+	node.ZstdCompressedEstimate = zs.Estimate
+}
+
+// sampleCompressNodes runs sampleCompressNode over nodes across a bounded
+// worker pool, so a --sample-compress run doesn't open thousands of files
+// at once.
+func sampleCompressNodes(nodes []*Node, sampleSize int64, workers int) {
+	if workers < 1 {
+		workers = defaultCompressWorkers
+	}
+
+	jobs := make(chan *Node)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for node := range jobs {
+				sampleCompressNode(node, sampleSize)
+			}
+		}()
+	}
+
+	for _, n := range nodes {
+		jobs <- n
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// DirSavings is one row of the --sample-compress ranked directory report.
+type DirSavings struct {
+	Dir          string `json:"dir"`
+	Usage        int64  `json:"usage"`
+	GzipEstimate int64  `json:"gzip_estimate"`
+	GzipSavings  int64  `json:"gzip_savings"`
+	ZstdEstimate int64  `json:"zstd_estimate"`
+	ZstdSavings  int64  `json:"zstd_savings"`
+}
+
+// TypeSavings is one row of the --sample-compress ranked file-type report.
+type TypeSavings struct {
+	Type         string `json:"type"`
+	Usage        int64  `json:"usage"`
+	GzipEstimate int64  `json:"gzip_estimate"`
+	GzipSavings  int64  `json:"gzip_savings"`
+	ZstdEstimate int64  `json:"zstd_estimate"`
+	ZstdSavings  int64  `json:"zstd_savings"`
+}
+
+// CompressReport is --sample-compress's output: directories and file
+// types ranked by how much disk switching to gzip or zstd at-rest storage
+// would reclaim, largest zstd savings first (zstd is the better codec in
+// the overwhelming majority of samples, so it drives the ranking).
+type CompressReport struct {
+	Directories []DirSavings  `json:"directories"`
+	Types       []TypeSavings `json:"types"`
+}
+
+// rankDirSavings aggregates sampled nodes by their parent directory and
+// ranks directories by potential zstd savings (usage - zstd estimate)
+// descending. Nodes skipped by sampleCompressNode (both ratios left at
+// their zero value) contribute their usage with no estimated savings.
+func rankDirSavings(nodes []*Node) []DirSavings {
+	byDir := make(map[string]*DirSavings)
+	for _, n := range nodes {
+		if n.IsDir {
+			continue
+		}
+		dir := filepath.Dir(n.FullPath)
+		row, ok := byDir[dir]
+		if !ok {
+			row = &DirSavings{Dir: dir}
+			byDir[dir] = row
+		}
+		row.Usage += n.Usage
+		row.GzipEstimate += gzipEstimateOrActual(n)
+		row.ZstdEstimate += zstdEstimateOrActual(n)
+	}
+
+	savings := make([]DirSavings, 0, len(byDir))
+	for _, row := range byDir {
+		row.GzipSavings = row.Usage - row.GzipEstimate
+		row.ZstdSavings = row.Usage - row.ZstdEstimate
+		savings = append(savings, *row)
+	}
+	sort.Slice(savings, func(i, j int) bool {
+		return savings[i].ZstdSavings > savings[j].ZstdSavings
+	})
+	return savings
+}
+
+// rankTypeSavings is rankDirSavings grouped by FileType instead of
+// directory.
+func rankTypeSavings(nodes []*Node) []TypeSavings {
+	byType := make(map[string]*TypeSavings)
+	for _, n := range nodes {
+		if n.IsDir {
+			continue
+		}
+		row, ok := byType[n.FileType]
+		if !ok {
+			row = &TypeSavings{Type: n.FileType}
+			byType[n.FileType] = row
+		}
+		row.Usage += n.Usage
+		row.GzipEstimate += gzipEstimateOrActual(n)
+		row.ZstdEstimate += zstdEstimateOrActual(n)
+	}
+
+	savings := make([]TypeSavings, 0, len(byType))
+	for _, row := range byType {
+		row.GzipSavings = row.Usage - row.GzipEstimate
+		row.ZstdSavings = row.Usage - row.ZstdEstimate
+		savings = append(savings, *row)
+	}
+	sort.Slice(savings, func(i, j int) bool {
+		return savings[i].ZstdSavings > savings[j].ZstdSavings
+	})
+	return savings
+}
+
+// gzipEstimateOrActual returns a node's CompressedEstimate, or its Usage
+// unchanged when it was never sampled (directories, already-compressed
+// types, or a read error), so skipped nodes don't masquerade as savings.
+func gzipEstimateOrActual(n *Node) int64 {
+	if n.Compressibility == 0 {
+		return n.Usage
+	}
+	return n.CompressedEstimate
+}
+
+// zstdEstimateOrActual is gzipEstimateOrActual for the zstd sample.
+func zstdEstimateOrActual(n *Node) int64 {
+	if n.ZstdCompressibility == 0 {
+		return n.Usage
+	}
+	return n.ZstdCompressedEstimate
+}
+
+// RunSampleCompress queries every file in db, estimates its compressed
+// size via sampled gzip and zstd passes, and writes a CompressReport
+// ranking directories and file types by reclaimable space to w as JSON
+// or YAML.
+func RunSampleCompress(db *DB, sampleSize int64, workers int, output string, w io.Writer) error {
+	nodes, err := db.QueryNodes("NOT is_dir", nil)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes: %w", err)
+	}
+
+	sampleCompressNodes(nodes, sampleSize, workers)
+
+	report := CompressReport{
+		Directories: rankDirSavings(nodes),
+		Types:       rankTypeSavings(nodes),
+	}
+
+	switch output {
+	case "", "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "yaml":
+		out, err := goyaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal yaml: %w", err)
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return fmt.Errorf("unknown --output format %q (want json or yaml)", output)
+	}
+}
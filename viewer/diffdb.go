@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DiffStatus classifies how a node changed between a baseline audit and
+// the current one.
+type DiffStatus int
+
+const (
+	DiffUnchanged DiffStatus = iota
+	DiffAdded
+	DiffRemoved
+	DiffGrew
+	DiffShrunk
+)
+
+// Icon returns the +/-/~ prefix used in the diff view.
+func (s DiffStatus) Icon() string {
+	switch s {
+	case DiffAdded:
+		return "+"
+	case DiffRemoved:
+		return "-"
+	case DiffGrew, DiffShrunk:
+		return "~"
+	default:
+		return " "
+	}
+}
+
+// DiffNode is a node from the current or baseline snapshot (or both),
+// tagged with how its size changed.
+type DiffNode struct {
+	*Node
+	Status     DiffStatus
+	DeltaUsage int64 // current usage - baseline usage (signed)
+	DeltaItems int64 // current item_count - baseline item_count (signed)
+}
+
+// DiffDB compares a current DB against a baseline DB captured from an
+// earlier audit, turning gdu-view into a periodic disk-audit differ.
+type DiffDB struct {
+	current  *DB
+	baseline *DB
+}
+
+// NewDiffDB wraps a current and baseline DB for diffing.
+func NewDiffDB(current, baseline *DB) *DiffDB {
+	return &DiffDB{current: current, baseline: baseline}
+}
+
+// GetChildren full-outer-joins the current and baseline parquet files on
+// path, restricted to the given parent directory, and returns one
+// DiffNode per row present in either snapshot, ordered by the magnitude of
+// their usage delta (biggest movers first).
+func (d *DiffDB) GetChildren(parentPath string) ([]*DiffNode, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(c.path, b.path)     AS path,
+			COALESCE(c.name, b.name)     AS name,
+			COALESCE(c.is_dir, b.is_dir) AS is_dir,
+			COALESCE(c.depth, b.depth)   AS depth,
+			COALESCE(c.usage, 0)         AS cur_usage,
+			COALESCE(c.item_count, 0)    AS cur_item_count,
+			COALESCE(b.usage, 0)         AS base_usage,
+			COALESCE(b.item_count, 0)    AS base_item_count,
+			(c.path IS NOT NULL)         AS in_current,
+			(b.path IS NOT NULL)         AS in_baseline
+		FROM (SELECT * FROM '%s' WHERE parent = ?) c
+		FULL OUTER JOIN (SELECT * FROM '%s' WHERE parent = ?) b
+			ON c.path = b.path
+	`, d.current.parquetPath, d.baseline.parquetPath)
+
+	rows, err := d.current.conn.Query(query, parentPath, parentPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff children: %w", err)
+	}
+	defer rows.Close()
+
+	var diffs []*DiffNode
+	for rows.Next() {
+		var (
+			path, name            string
+			isDir                 bool
+			depth                 int
+			curUsage, curItems    int64
+			baseUsage, baseItems  int64
+			inCurrent, inBaseline bool
+		)
+		if err := rows.Scan(&path, &name, &isDir, &depth,
+			&curUsage, &curItems, &baseUsage, &baseItems,
+			&inCurrent, &inBaseline); err != nil {
+			return nil, fmt.Errorf("failed to scan diff row: %w", err)
+		}
+
+		n := &DiffNode{
+			Node: &Node{
+				Name:     name,
+				FullPath: path,
+				Usage:    curUsage,
+				IsDir:    isDir,
+				Depth:    depth,
+			},
+			DeltaUsage: curUsage - baseUsage,
+			DeltaItems: curItems - baseItems,
+		}
+		if isDir {
+			n.FileType = "dir"
+		} else {
+			n.FileType = getFileType(name)
+			n.Extension = getExtension(name)
+		}
+
+		switch {
+		case !inBaseline:
+			n.Status = DiffAdded
+			n.ItemCount = curItems
+		case !inCurrent:
+			n.Status = DiffRemoved
+			n.Usage = baseUsage
+			n.ItemCount = baseItems
+			n.DeltaUsage = -baseUsage
+			n.DeltaItems = -baseItems
+		case curUsage > baseUsage:
+			n.Status = DiffGrew
+			n.ItemCount = curItems
+		case curUsage < baseUsage:
+			n.Status = DiffShrunk
+			n.ItemCount = curItems
+		default:
+			n.Status = DiffUnchanged
+			n.ItemCount = curItems
+		}
+
+		diffs = append(diffs, n)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return abs64(diffs[i].DeltaUsage) > abs64(diffs[j].DeltaUsage)
+	})
+
+	return diffs, nil
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
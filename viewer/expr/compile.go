@@ -0,0 +1,58 @@
+package expr
+
+import "fmt"
+
+// sqlColumns maps expr field selectors to parquet column names for fields
+// that exist directly in the schema. "type" has no backing column (it's
+// derived from the file name in Go) so it is never pushed down; callers
+// must also run Eval over the result to apply any .type predicate.
+var sqlColumns = map[string]string{
+	"name":       "name",
+	"path":       "path",
+	"size":       "size",
+	"is_dir":     "is_dir",
+	"item_count": "item_count",
+}
+
+// CompileWhere lowers p to a parameterized SQL WHERE clause (without the
+// "WHERE" keyword) plus its bind arguments, for the subset of fields that
+// map directly to parquet columns. ok is false when p references a field
+// (such as "type") that can't be pushed down; callers should still apply
+// the full predicate in Go via Eval against the (unfiltered-by-that-field)
+// rows.
+func CompileWhere(p Predicate) (clause string, args []any, ok bool) {
+	if p == nil {
+		return "", nil, true
+	}
+	return compile(p)
+}
+
+func compile(p Predicate) (string, []any, bool) {
+	switch n := p.(type) {
+	case Comparison:
+		col, known := sqlColumns[n.Field]
+		if !known {
+			return "", nil, false
+		}
+		op := n.Op
+		if op == "glob" {
+			if !n.Value.IsStr {
+				return "", nil, false
+			}
+			return fmt.Sprintf("%s GLOB ?", col), []any{n.Value.Str}, true
+		}
+		if n.Value.IsStr {
+			return fmt.Sprintf("%s %s ?", col, op), []any{n.Value.Str}, true
+		}
+		return fmt.Sprintf("%s %s ?", col, op), []any{n.Value.Num}, true
+
+	case Logical:
+		lc, la, lok := compile(n.Left)
+		rc, ra, rok := compile(n.Right)
+		if !lok || !rok {
+			return "", nil, false
+		}
+		return fmt.Sprintf("(%s %s %s)", lc, n.Op, rc), append(la, ra...), true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,239 @@
+package expr
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// Record is a single row of field values, keyed by selector name without
+// the leading dot (e.g. "size", "type", "is_dir").
+type Record map[string]any
+
+// Eval filters and shapes records according to q. It is the fallback (and,
+// for "type", the only) evaluator for anything CompileWhere couldn't push
+// down to SQL, plus the full pipeline of terminal stages (sort/take/
+// group_by/sum).
+func Eval(records []Record, q *Query) ([]Record, error) {
+	if q.Filter != nil {
+		filtered := make([]Record, 0, len(records))
+		for _, r := range records {
+			ok, err := matches(r, q.Filter)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				filtered = append(filtered, r)
+			}
+		}
+		records = filtered
+	}
+
+	var groupField string
+	grouping := false
+
+	for _, stage := range q.Pipeline {
+		switch stage.Name {
+		case "sort":
+			if len(stage.Args) < 1 {
+				return nil, fmt.Errorf("expr: sort() requires a field argument")
+			}
+			desc := true
+			if len(stage.Args) > 1 && stage.Args[1].Str == "asc" {
+				desc = false
+			}
+			sortRecords(records, stage.Args[0].Str, desc)
+
+		case "take":
+			if len(stage.Args) < 1 {
+				return nil, fmt.Errorf("expr: take() requires a count argument")
+			}
+			n := int(stage.Args[0].Num)
+			if n < 0 {
+				n = 0
+			}
+			if n < len(records) {
+				records = records[:n]
+			}
+
+		case "group_by":
+			if len(stage.Args) < 1 {
+				return nil, fmt.Errorf("expr: group_by() requires a field argument")
+			}
+			groupField = stage.Args[0].Str
+			grouping = true
+
+		case "sum":
+			if !grouping {
+				return nil, fmt.Errorf("expr: sum() must follow group_by()")
+			}
+			if len(stage.Args) < 1 {
+				return nil, fmt.Errorf("expr: sum() requires a field argument")
+			}
+			records = groupSum(records, groupField, stage.Args[0].Str)
+			grouping = false
+
+		default:
+			return nil, fmt.Errorf("expr: unknown pipeline stage %q", stage.Name)
+		}
+	}
+
+	return records, nil
+}
+
+func matches(r Record, p Predicate) (bool, error) {
+	switch n := p.(type) {
+	case Comparison:
+		val, ok := r[n.Field]
+		if !ok {
+			return false, nil
+		}
+		return compareValue(val, n.Op, n.Value)
+
+	case Logical:
+		left, err := matches(r, n.Left)
+		if err != nil {
+			return false, err
+		}
+		if n.Op == "&&" && !left {
+			return false, nil
+		}
+		if n.Op == "||" && left {
+			return true, nil
+		}
+		return matches(r, n.Right)
+	}
+	return false, fmt.Errorf("expr: unknown predicate type %T", p)
+}
+
+func compareValue(actual any, op string, want Value) (bool, error) {
+	switch v := actual.(type) {
+	case string:
+		if op == "glob" {
+			return filepath.Match(want.Str, v)
+		}
+		switch op {
+		case "==":
+			return v == want.Str, nil
+		case "!=":
+			return v != want.Str, nil
+		default:
+			return false, fmt.Errorf("expr: operator %q is not valid for a string field", op)
+		}
+	case bool:
+		switch op {
+		case "==":
+			return v == (want.Str == "true"), nil
+		case "!=":
+			return v != (want.Str == "true"), nil
+		default:
+			return false, fmt.Errorf("expr: operator %q is not valid for a bool field", op)
+		}
+	default:
+		f, ok := toFloat(actual)
+		if !ok {
+			return false, fmt.Errorf("expr: unsupported field value type %T", actual)
+		}
+		switch op {
+		case ">":
+			return f > want.Num, nil
+		case "<":
+			return f < want.Num, nil
+		case ">=":
+			return f >= want.Num, nil
+		case "<=":
+			return f <= want.Num, nil
+		case "==":
+			return f == want.Num, nil
+		case "!=":
+			return f != want.Num, nil
+		default:
+			return false, fmt.Errorf("expr: unknown operator %q", op)
+		}
+	}
+}
+
+func sortRecords(records []Record, field string, desc bool) {
+	sort.SliceStable(records, func(i, j int) bool {
+		c := compareAny(records[i][field], records[j][field])
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func compareAny(a, b any) int {
+	if af, aok := toFloat(a); aok {
+		if bf, bok := toFloat(b); bok {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	as, bs := fmt.Sprint(a), fmt.Sprint(b)
+	switch {
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// groupSum buckets records by groupField and sums sumField within each
+// bucket, producing one record per distinct group value, sorted by the
+// summed total descending.
+func groupSum(records []Record, groupField, sumField string) []Record {
+	type bucket struct {
+		key   any
+		total float64
+	}
+
+	var order []any
+	buckets := make(map[any]*bucket)
+
+	for _, r := range records {
+		key := r[groupField]
+		v, _ := toFloat(r[sumField])
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{key: key}
+			buckets[key] = b
+			order = append(order, key)
+		}
+		b.total += v
+	}
+
+	sumKey := "sum_" + sumField
+	out := make([]Record, 0, len(order))
+	for _, key := range order {
+		b := buckets[key]
+		out = append(out, Record{groupField: b.key, sumKey: b.total})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i][sumKey].(float64) > out[j][sumKey].(float64)
+	})
+
+	return out
+}
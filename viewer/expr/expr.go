@@ -0,0 +1,366 @@
+// Package expr implements a small expression language for filtering and
+// shaping gdu-view's node data from the command line, e.g.:
+//
+//	.size > 10M && .type == "media"
+//	.type == "data" .sort("size").take(20)
+//	.group_by("type").sum("size")
+//
+// A Query is a field predicate (optional) followed by a pipeline of
+// terminal stages (optional). Parse turns source text into a Query; the
+// caller lowers the predicate to SQL where possible (see CompileWhere) and
+// falls back to Eval for anything the predicate/pipeline needs that SQL
+// doesn't cover (notably the computed "type" field).
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value is a literal in a comparison or a stage argument.
+type Value struct {
+	Str   string
+	Num   float64
+	IsStr bool
+}
+
+func (v Value) String() string {
+	if v.IsStr {
+		return v.Str
+	}
+	return strconv.FormatFloat(v.Num, 'g', -1, 64)
+}
+
+// Predicate is a boolean expression over node fields.
+type Predicate interface {
+	isPredicate()
+}
+
+// Comparison is a single `.field op value` test.
+type Comparison struct {
+	Field string
+	Op    string // ">", "<", ">=", "<=", "==", "!=", "glob"
+	Value Value
+}
+
+func (Comparison) isPredicate() {}
+
+// Logical combines two predicates with && or ||.
+type Logical struct {
+	Op          string // "&&" or "||"
+	Left, Right Predicate
+}
+
+func (Logical) isPredicate() {}
+
+// Stage is a terminal pipeline call, e.g. sort("size") or take(20).
+type Stage struct {
+	Name string
+	Args []Value
+}
+
+// Query is a parsed expression: an optional filter predicate followed by
+// an optional pipeline of stages.
+type Query struct {
+	Filter   Predicate
+	Pipeline []Stage
+}
+
+// Parse parses source into a Query.
+func Parse(src string) (*Query, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+
+	q := &Query{}
+	if p.peek().kind == tokDot && p.looksLikeComparison() {
+		pred, err := p.parsePredicate()
+		if err != nil {
+			return nil, err
+		}
+		q.Filter = pred
+	}
+
+	for p.peek().kind == tokDot {
+		stage, err := p.parseStage()
+		if err != nil {
+			return nil, err
+		}
+		q.Pipeline = append(q.Pipeline, stage)
+	}
+
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("expr: unexpected input at %q", p.peek().text)
+	}
+
+	return q, nil
+}
+
+// --- lexer ---
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokDot
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+	tokAnd
+	tokOr
+)
+
+type token struct {
+	kind tokKind
+	text string
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	r := []rune(src)
+	i := 0
+	for i < len(r) {
+		c := r[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '.':
+			toks = append(toks, token{tokDot, "."})
+			i++
+		case c == '(':
+			toks = append(toks, token{tokLParen, "("})
+			i++
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")"})
+			i++
+		case c == ',':
+			toks = append(toks, token{tokComma, ","})
+			i++
+		case c == '&' && i+1 < len(r) && r[i+1] == '&':
+			toks = append(toks, token{tokAnd, "&&"})
+			i += 2
+		case c == '|' && i+1 < len(r) && r[i+1] == '|':
+			toks = append(toks, token{tokOr, "||"})
+			i += 2
+		case strings.ContainsRune("><=!", c):
+			op := string(c)
+			i++
+			if i < len(r) && r[i] == '=' {
+				op += "="
+				i++
+			}
+			if op == "=" {
+				return nil, fmt.Errorf("expr: unexpected '=', did you mean '=='?")
+			}
+			toks = append(toks, token{tokOp, op})
+		case c == '"':
+			j := i + 1
+			for j < len(r) && r[j] != '"' {
+				j++
+			}
+			if j >= len(r) {
+				return nil, fmt.Errorf("expr: unterminated string literal")
+			}
+			toks = append(toks, token{tokString, string(r[i+1 : j])})
+			i = j + 1
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(r) && (r[j] >= '0' && r[j] <= '9' || r[j] == '.') {
+				j++
+			}
+			num := string(r[i:j])
+			// optional size suffix: K, M, G, T (binary multiples)
+			if j < len(r) && strings.ContainsRune("KMGTkmgt", r[j]) {
+				num += string(r[j])
+				j++
+			}
+			toks = append(toks, token{tokNumber, num})
+			i = j
+		case isIdentStart(c):
+			j := i
+			for j < len(r) && isIdentPart(r[j]) {
+				j++
+			}
+			toks = append(toks, token{tokIdent, string(r[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("expr: unexpected character %q", c)
+		}
+	}
+	toks = append(toks, token{tokEOF, ""})
+	return toks, nil
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c rune) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// parseNumber turns a lexed number token (with optional K/M/G/T suffix)
+// into a byte count.
+func parseNumber(text string) (float64, error) {
+	mult := 1.0
+	suffix := text[len(text)-1]
+	switch suffix {
+	case 'K', 'k':
+		mult = 1 << 10
+		text = text[:len(text)-1]
+	case 'M', 'm':
+		mult = 1 << 20
+		text = text[:len(text)-1]
+	case 'G', 'g':
+		mult = 1 << 30
+		text = text[:len(text)-1]
+	case 'T', 't':
+		mult = 1 << 40
+		text = text[:len(text)-1]
+	}
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expr: invalid number %q: %w", text, err)
+	}
+	return n * mult, nil
+}
+
+// --- parser ---
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.toks) {
+		return token{kind: tokEOF}
+	}
+	return p.toks[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if p.pos < len(p.toks)-1 {
+		p.pos++
+	}
+	return t
+}
+
+// looksLikeComparison distinguishes a leading ".field op value" predicate
+// from a leading ".stage(args)" pipeline call: in a predicate the token
+// after the field name (the third token) is an operator or the glob
+// keyword, in a stage call it is '('.
+func (p *parser) looksLikeComparison() bool {
+	third := p.peekAt(2)
+	return third.kind == tokOp || (third.kind == tokIdent && third.text == "glob")
+}
+
+func (p *parser) parsePredicate() (Predicate, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd || p.peek().kind == tokOr {
+		op := p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		opText := "&&"
+		if op.kind == tokOr {
+			opText = "||"
+		}
+		left = Logical{Op: opText, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComparison() (Predicate, error) {
+	if p.peek().kind != tokDot {
+		return nil, fmt.Errorf("expr: expected field selector, got %q", p.peek().text)
+	}
+	p.next()
+	field := p.next()
+	if field.kind != tokIdent {
+		return nil, fmt.Errorf("expr: expected field name after '.'")
+	}
+
+	var op string
+	if p.peek().kind == tokOp {
+		op = p.next().text
+	} else if p.peek().kind == tokIdent && p.peek().text == "glob" {
+		op = p.next().text
+	} else {
+		return nil, fmt.Errorf("expr: expected comparison operator after .%s", field.text)
+	}
+
+	val, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return Comparison{Field: field.text, Op: op, Value: val}, nil
+}
+
+func (p *parser) parseValue() (Value, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return Value{Str: t.text, IsStr: true}, nil
+	case tokNumber:
+		n, err := parseNumber(t.text)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Num: n}, nil
+	case tokIdent:
+		if t.text == "true" || t.text == "false" {
+			return Value{Str: t.text, IsStr: true}, nil
+		}
+	}
+	return Value{}, fmt.Errorf("expr: expected value, got %q", t.text)
+}
+
+func (p *parser) parseStage() (Stage, error) {
+	p.next() // '.'
+	name := p.next()
+	if name.kind != tokIdent {
+		return Stage{}, fmt.Errorf("expr: expected stage name after '.'")
+	}
+	if p.peek().kind != tokLParen {
+		return Stage{}, fmt.Errorf("expr: expected '(' after .%s", name.text)
+	}
+	p.next()
+
+	var args []Value
+	for p.peek().kind != tokRParen {
+		val, err := p.parseValue()
+		if err != nil {
+			return Stage{}, err
+		}
+		args = append(args, val)
+		if p.peek().kind == tokComma {
+			p.next()
+			continue
+		}
+		break
+	}
+	if p.peek().kind != tokRParen {
+		return Stage{}, fmt.Errorf("expr: expected ')' to close .%s(...)", name.text)
+	}
+	p.next()
+
+	return Stage{Name: name.text, Args: args}, nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"os"
+)
+
+// sniffLen is how many header bytes DetectFileType inspects; generous
+// enough to cover every signature below without reading whole files.
+const sniffLen = 512
+
+// FileTypeMismatch records a file whose extension disagrees with what its
+// content actually sniffs as, surfaced by --deep-scan.
+type FileTypeMismatch struct {
+	Path       string
+	Extension  string
+	SniffedExt string
+	MIME       string
+}
+
+type fileSignature struct {
+	magic               []byte
+	category, mime, ext string
+}
+
+// Known magic numbers, checked in order against a file's first sniffLen
+// bytes. PK\x03\x04-based office formats (xlsx/docx/pptx) all share the
+// zip signature; without unzipping to inspect [Content_Types].xml there's
+// no cheap way to tell them apart, so they're reported as "zip".
+var fileSignatures = []fileSignature{
+	{[]byte{0x1f, 0x8b}, "archive", "application/gzip", ".gz"},
+	{[]byte{0x28, 0xb5, 0x2f, 0xfd}, "archive", "application/zstd", ".zst"},
+	{[]byte("PK\x03\x04"), "archive", "application/zip", ".zip"},
+	{[]byte{0x37, 0x7a, 0xbc, 0xaf, 0x27, 0x1c}, "archive", "application/x-7z-compressed", ".7z"},
+	{[]byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}, "media", "image/png", ".png"},
+	{[]byte{0xff, 0xd8, 0xff}, "media", "image/jpeg", ".jpg"},
+	{[]byte("%PDF"), "doc", "application/pdf", ".pdf"},
+	{[]byte("SQLite format 3\x00"), "data", "application/vnd.sqlite3", ".sqlite"},
+	{[]byte{0x89, 'H', 'D', 'F'}, "data", "application/x-hdf5", ".h5"},
+	{[]byte("PAR1"), "data", "application/vnd.apache.parquet", ".parquet"},
+	{[]byte{0x7f, 'E', 'L', 'F'}, "other", "application/x-elf", ""},
+	{[]byte{0xfe, 0xed, 0xfa, 0xce}, "other", "application/x-mach-binary", ""},
+	{[]byte{0xfe, 0xed, 0xfa, 0xcf}, "other", "application/x-mach-binary", ""},
+	{[]byte{0xcf, 0xfa, 0xed, 0xfe}, "other", "application/x-mach-binary", ""},
+	{[]byte{0xce, 0xfa, 0xed, 0xfe}, "other", "application/x-mach-binary", ""},
+	{[]byte("MZ"), "other", "application/vnd.microsoft.portable-executable", ".exe"},
+}
+
+// DetectFileType sniffs headerBytes (the first ~sniffLen bytes read from
+// path) against known magic numbers and returns the category, MIME type,
+// and canonical extension it matches. Parquet's PAR1 magic also appears
+// at the very end of a well-formed file, so a PAR1 header match is
+// confirmed by re-opening path to check its last 4 bytes; a header-only
+// match (e.g. a truncated file) falls through rather than false-positive.
+// Returns ("other", "application/octet-stream", "") when nothing matches.
+func DetectFileType(path string, headerBytes []byte) (category, mime, ext string) {
+	for _, sig := range fileSignatures {
+		if len(headerBytes) < len(sig.magic) {
+			continue
+		}
+		if !bytes.Equal(headerBytes[:len(sig.magic)], sig.magic) {
+			continue
+		}
+		if sig.ext == ".parquet" && !hasParquetFooter(path) {
+			continue
+		}
+		return sig.category, sig.mime, sig.ext
+	}
+	return "other", "application/octet-stream", ""
+}
+
+// hasParquetFooter reports whether path's last 4 bytes are the PAR1
+// magic number that closes every well-formed Parquet file.
+func hasParquetFooter(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.Size() < 4 {
+		return false
+	}
+
+	buf := make([]byte, 4)
+	if _, err := f.ReadAt(buf, info.Size()-4); err != nil {
+		return false
+	}
+	return bytes.Equal(buf, []byte("PAR1"))
+}
+
+// sniffHeader reads up to sniffLen bytes from the start of path for
+// DetectFileType to match against.
+func sniffHeader(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := f.Read(buf)
+	if err != nil && n == 0 {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// DeepScanNode re-derives node's FileType/Extension from its real content,
+// assuming node.FullPath is reachable on this machine (true whenever
+// gdu-view runs on the host the audit was taken on, which --deep-scan
+// assumes). It mutates node in place and returns a non-nil mismatch when
+// the sniffed type disagrees with what the extension implied; directories,
+// unreadable files, and files whose content sniffs as nothing recognizable
+// are left untouched.
+func DeepScanNode(node *Node) *FileTypeMismatch {
+	if node.IsDir {
+		return nil
+	}
+
+	header, err := sniffHeader(node.FullPath)
+	if err != nil || len(header) == 0 {
+		return nil
+	}
+
+	category, mime, ext := DetectFileType(node.FullPath, header)
+	if ext == "" || ext == node.Extension {
+		return nil
+	}
+
+	mismatch := &FileTypeMismatch{
+		Path:       node.FullPath,
+		Extension:  node.Extension,
+		SniffedExt: ext,
+		MIME:       mime,
+	}
+
+	node.FileType = category
+	node.Extension = ext
+
+	return mismatch
+}
+
+// recordMismatches merges newly found mismatches into m.mismatches,
+// skipping any path already recorded (the same directory can be
+// revisited, and a cache hit re-sniffs already-corrected nodes, which
+// never re-mismatch, but belt-and-suspenders here costs nothing).
+func (m *model) recordMismatches(found []FileTypeMismatch) {
+	if len(found) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool, len(m.mismatches))
+	for _, existing := range m.mismatches {
+		seen[existing.Path] = true
+	}
+	for _, mm := range found {
+		if !seen[mm.Path] {
+			m.mismatches = append(m.mismatches, mm)
+			seen[mm.Path] = true
+		}
+	}
+}
+
+// deepScanMismatches runs DeepScanNode over nodes when enabled is true
+// (the --deep-scan flag), collecting whatever disagrees with its
+// extension. Load commands call this and thread the result back through
+// their message so Update can merge it into m.mismatches, since the
+// commands themselves run on a throwaway copy of model.
+func deepScanMismatches(enabled bool, nodes []*Node) []FileTypeMismatch {
+	if !enabled {
+		return nil
+	}
+
+	var mismatches []FileTypeMismatch
+	for _, n := range nodes {
+		if mismatch := DeepScanNode(n); mismatch != nil {
+			mismatches = append(mismatches, *mismatch)
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+
+	"github.com/muesli/termenv"
+)
+
+// iconExt maps specific extensions to Nerd Font codepoints, mirroring
+// exa/nu-command's icons modules. Only extensions with a meaningfully
+// distinct glyph are listed; everything else falls back to iconCategory.
+var iconExt = map[string]rune{
+	".go":      '',
+	".py":      '',
+	".rs":      '',
+	".js":      '',
+	".jsx":     '',
+	".ts":      '',
+	".tsx":     '',
+	".java":    '',
+	".rb":      '',
+	".php":     '',
+	".c":       '',
+	".cpp":     '',
+	".h":       '',
+	".hpp":     '',
+	".sh":      '',
+	".bash":    '',
+	".md":      '',
+	".json":    '',
+	".yaml":    '',
+	".yml":     '',
+	".csv":     '',
+	".parquet": '',
+	".sql":     '',
+	".zip":     '',
+	".tar":     '',
+	".gz":      '',
+	".7z":      '',
+	".zst":     '',
+	".pdf":     '',
+	".png":     '',
+	".jpg":     '',
+	".jpeg":    '',
+	".gif":     '',
+	".mp4":     '',
+	".mp3":     '',
+	".ipynb":   '',
+}
+
+// iconCategory is the per-FileType-category fallback consulted when an
+// extension isn't listed in iconExt.
+var iconCategory = map[string]rune{
+	"code":    '',
+	"data":    '',
+	"media":   '',
+	"archive": '',
+	"doc":     '',
+	"config":  '',
+}
+
+const (
+	iconDir     = '' // folder
+	iconDefault = '' // generic file
+)
+
+// IconFor returns the Nerd Font glyph for node: a specific-extension
+// match first, then its FileType category, then a generic file glyph.
+// Exposed so non-CLI consumers (JSON/HTML export) can request icons
+// without going through the TUI renderer.
+func IconFor(node *Node) rune {
+	if node.IsDir {
+		return iconDir
+	}
+	if icon, ok := iconExt[node.Extension]; ok {
+		return icon
+	}
+	if icon, ok := iconCategory[node.FileType]; ok {
+		return icon
+	}
+	return iconDefault
+}
+
+// iconsEnabled is the resolved state of --icons: whether renderers should
+// draw Nerd Font glyphs (true) or fall back to the plain [X] bracket
+// codes (false). Set by applyIconMode.
+var iconsEnabled = false
+
+// ResolveIconMode turns --icons's value into whether glyphs should
+// render: "always" forces them on, "never" forces them off, and "auto"
+// (the default) renders them unless NO_COLOR is set or the terminal's
+// color profile can't do better than plain ASCII — the same signal
+// resolveTheme uses to fall back to the ascii theme, since a Nerd Font
+// glyph is as useless as a truecolor escape on a terminal that can't
+// render either.
+func ResolveIconMode(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	default:
+		if os.Getenv("NO_COLOR") != "" {
+			return false
+		}
+		return termenv.ColorProfile() != termenv.Ascii
+	}
+}
+
+// applyIconMode sets the package-wide icon rendering state for the rest
+// of this run.
+func applyIconMode(enabled bool) {
+	iconsEnabled = enabled
+}
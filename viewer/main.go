@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -9,15 +10,87 @@ import (
 )
 
 func main() {
-	if len(os.Args) < 2 {
+	if len(os.Args) > 1 && os.Args[1] == "mount" {
+		runMountCmd(os.Args[2:])
+		return
+	}
+
+	themeFlag := flag.String("theme", "", "theme to use (also settable via GDU_VIEW_THEME); see --themes")
+	showThemes := flag.Bool("themes", false, "preview every built-in theme and exit")
+	exportThemes := flag.Bool("export-themes", false, "write every built-in theme as JSON to stdout and exit")
+	reduceExpr := flag.String("reduce", "", "non-interactive: evaluate an expr query against the parquet and print results (see -r)")
+	flag.StringVar(reduceExpr, "r", "", "shorthand for --reduce")
+	output := flag.String("output", "json", "output format for --reduce: json or yaml")
+	baseline := flag.String("baseline", "", "path to an earlier audit's parquet; enables the [~] Diff view comparing it against <file.parquet>")
+	deepScan := flag.Bool("deep-scan", false, "sniff each file's real content against its extension as directories load, flagging mismatches in the Types view (requires the audited paths to still be reachable on this machine)")
+	sampleCompress := flag.Bool("sample-compress", false, "non-interactive: gzip- and zstd-sample every file to estimate reclaimable space, ranked by directory and file type (see --output for output format)")
+	sampleSizeKB := flag.Int("compress-sample-size", defaultSampleSize/1024, "KB sampled per file for --sample-compress")
+	compressWorkers := flag.Int("compress-workers", defaultCompressWorkers, "worker pool size for --sample-compress")
+	profileFlag := flag.String("profile", "", "domain taxonomy profile layered over the default file-type categorization (bioinformatics, imaging, ml, notebook, or auto to detect from the audit's extensions)")
+	taxonomyFile := flag.String("taxonomy", "", "path to a YAML file of custom file-type rules, layered over --profile and the default taxonomy")
+	iconsFlag := flag.String("icons", "auto", "render Nerd Font glyphs instead of [X] bracket codes: auto, always, or never")
+	flag.Parse()
+
+	applyIconMode(ResolveIconMode(*iconsFlag))
+
+	if *exportThemes {
+		if err := ExportThemesJSON(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to export themes: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	applyTheme(resolveTheme(*themeFlag))
+
+	if *showThemes {
+		PrintThemePreviews(os.Stdout)
+		return
+	}
+
+	if flag.NArg() < 1 {
 		fmt.Println(lipgloss.NewStyle().
 			Foreground(colorDanger).
 			Bold(true).
-			Render("Usage: gdu-view <file.parquet>"))
+			Render("Usage: gdu-view [--theme name] [--themes] [--export-themes] <file.parquet>"))
 		os.Exit(1)
 	}
 
-	fileName := os.Args[1]
+	fileName := flag.Arg(0)
+
+	if *sampleCompress {
+		db, err := OpenDB(fileName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open database: %v\n", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		applyResolvedTaxonomy(db, *profileFlag, *taxonomyFile)
+
+		sampleSize := int64(*sampleSizeKB) * 1024
+		if err := RunSampleCompress(db, sampleSize, *compressWorkers, *output, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	reducing := *reduceExpr != "" || !stdoutIsTTY()
+	if reducing {
+		db, err := OpenDB(fileName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open database: %v\n", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		applyResolvedTaxonomy(db, *profileFlag, *taxonomyFile)
+
+		if err := RunReducer(db, *reduceExpr, *output, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Open database
 	loadStyle := lipgloss.NewStyle().Foreground(colorAccent)
@@ -31,6 +104,7 @@ func main() {
 		os.Exit(1)
 	}
 	defer db.Close()
+	applyResolvedTaxonomy(db, *profileFlag, *taxonomyFile)
 
 	fmt.Print("\r" + loadStyle.Render("Loading stats...   "))
 
@@ -57,9 +131,25 @@ func main() {
 		Render(fmt.Sprintf("Loaded %d items (%d files, %d dirs)",
 			stats.TotalItems, stats.TotalFiles, stats.TotalDirs)))
 
+	var diffDB *DiffDB
+	if *baseline != "" {
+		baselineDB, err := OpenDB(*baseline)
+		if err != nil {
+			fmt.Printf("%s\n", lipgloss.NewStyle().
+				Foreground(colorDanger).
+				Render(fmt.Sprintf("Failed to open baseline database: %v", err)))
+			os.Exit(1)
+		}
+		defer baselineDB.Close()
+		diffDB = NewDiffDB(db, baselineDB)
+	}
+
+	stopPprof := startPprof()
+	defer stopPprof()
+
 	// Start TUI
 	p := tea.NewProgram(
-		initialModel(db, stats, rootPath, fileName),
+		initialModel(db, stats, rootPath, fileName, diffDB, *deepScan),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
@@ -69,3 +159,40 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// applyResolvedTaxonomy resolves --profile/--taxonomy against db and
+// installs the result as the active taxonomy, exiting on a bad profile
+// name or an unreadable/malformed taxonomy file.
+func applyResolvedTaxonomy(db *DB, profile, taxonomyFile string) {
+	t, err := ResolveTaxonomy(profile, taxonomyFile, db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	applyTaxonomy(t)
+}
+
+// runMountCmd implements `gdu-view mount <file.parquet> <mountpoint>`,
+// serving the audited tree as a read-only FUSE filesystem until the mount
+// is interrupted or unmounted.
+func runMountCmd(args []string) {
+	fs := flag.NewFlagSet("mount", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: gdu-view mount <file.parquet> <mountpoint>")
+		os.Exit(1)
+	}
+
+	db, err := OpenDB(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open database: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	if err := RunMount(db, fs.Arg(1)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
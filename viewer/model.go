@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -20,10 +22,17 @@ const (
 	ViewFlat
 	ViewTypes
 	ViewLargest
+	ViewDiff
+	ViewTreemap
+	ViewCart
 )
 
+// numViewModes is the modulus the View key cycles through. ViewDiff is
+// skipped by that cycle unless a baseline was loaded (see m.diffDB).
+const numViewModes = 7
+
 func (v ViewMode) String() string {
-	return [...]string{"[D] Tree", "[=] Flat", "[#] Types", "[!] Largest"}[v]
+	return [...]string{"[D] Tree", "[=] Flat", "[#] Types", "[!] Largest", "[~] Diff", "[%] Treemap", "[x] Cart"}[v]
 }
 
 // SortMode represents the current sort mode.
@@ -40,43 +49,69 @@ func (s SortMode) String() string {
 	return [...]string{"Size v", "Name ^", "Type", "Items v"}[s]
 }
 
+// DiffSortMode orders ViewDiff's rows; it has its own cycle (see the Sort
+// key handler) since "biggest gainers"/"biggest losers" only make sense
+// for a diff, not a plain node list.
+type DiffSortMode int
+
+const (
+	DiffSortMagnitude DiffSortMode = iota // biggest movers, either direction
+	DiffSortGainers                       // biggest growth first
+	DiffSortLosers                        // biggest shrinkage first
+	DiffSortName
+)
+
+func (s DiffSortMode) String() string {
+	return [...]string{"Movers", "Gainers v", "Losers ^", "Name ^"}[s]
+}
+
 // Key bindings
 type keyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Left     key.Binding
-	Right    key.Binding
-	Enter    key.Binding
-	Back     key.Binding
-	Search   key.Binding
-	View     key.Binding
-	Sort     key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
-	Top      key.Binding
-	Bottom   key.Binding
-	Escape   key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Left       key.Binding
+	Right      key.Binding
+	Enter      key.Binding
+	Back       key.Binding
+	Search     key.Binding
+	View       key.Binding
+	Sort       key.Binding
+	Help       key.Binding
+	Quit       key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	Top        key.Binding
+	Bottom     key.Binding
+	Escape     key.Binding
+	Yank       key.Binding
+	YankAll    key.Binding
+	Export     key.Binding
+	Cart       key.Binding
+	CartExport key.Binding
 }
 
 var keys = keyMap{
-	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("^/k", "up")),
-	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("v/j", "down")),
-	Left:     key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("<-/h", "back")),
-	Right:    key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("->/l", "open")),
-	Enter:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
-	Back:     key.NewBinding(key.WithKeys("backspace"), key.WithHelp("bksp", "back")),
-	Search:   key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-	View:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "view mode")),
-	Sort:     key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort")),
-	Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
-	Quit:     key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
-	PageUp:   key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
-	PageDown: key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdn", "page down")),
-	Top:      key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "top")),
-	Bottom:   key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "bottom")),
-	Escape:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	Up:         key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("^/k", "up")),
+	Down:       key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("v/j", "down")),
+	Left:       key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("<-/h", "back")),
+	Right:      key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("->/l", "open")),
+	Enter:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
+	Back:       key.NewBinding(key.WithKeys("backspace"), key.WithHelp("bksp", "back")),
+	Search:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+	View:       key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "view mode")),
+	Sort:       key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort")),
+	Help:       key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+	Quit:       key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	PageUp:     key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
+	PageDown:   key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdn", "page down")),
+	Top:        key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "top")),
+	Bottom:     key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "bottom")),
+	Escape:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	Yank:       key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "yank path")),
+	YankAll:    key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "yank listing")),
+	Export:     key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "export view")),
+	Cart:       key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark for cart")),
+	CartExport: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "export cart")),
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -88,6 +123,8 @@ func (k keyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.Top, k.Bottom},
 		{k.Enter, k.Right, k.Back, k.Left},
 		{k.Search, k.View, k.Sort},
+		{k.Yank, k.YankAll, k.Export},
+		{k.Cart, k.CartExport},
 		{k.Help, k.Quit, k.Escape},
 	}
 }
@@ -114,9 +151,24 @@ type model struct {
 	largestFiles []*Node        // Top 100 largest files (lazy loaded)
 	typeStats    []FileTypeStat // File type statistics (lazy loaded)
 
+	// Diff view, only populated when a --baseline parquet was given.
+	diffDB       *DiffDB
+	diffChildren []*DiffNode // ViewDiff rows for currentPath (lazy loaded)
+	diffSortMode DiffSortMode
+
 	// Global stats (loaded once at startup)
 	stats *GlobalStats
 
+	// Cart: nodes marked for deletion, keyed by FullPath so the same node
+	// toggles off regardless of which view it was marked from.
+	cart map[string]*Node
+
+	// deepScan enables --deep-scan: as directories load, files are sniffed
+	// by content and anything that disagrees with its extension is
+	// recorded in mismatches for the Types view to report.
+	deepScan   bool
+	mismatches []FileTypeMismatch
+
 	// UI state
 	cursor      int
 	offset      int // For scrolling
@@ -129,15 +181,28 @@ type model struct {
 	searchInput textinput.Model
 	searchQuery string
 	filtered    []*Node
+	matches     [][]int // rune indexes into searchKey(filtered[i]), aligned with filtered
 	help        help.Model
 	fileName    string
 
+	// Cart export path prompt, active after pressing CartExport.
+	exporting   bool
+	exportInput textinput.Model
+
+	// Transient status message (e.g. "Copied to clipboard"), shown in the
+	// help bar and cleared a few seconds after it's set. statusMsgID
+	// guards against a stale clearStatusMsg clearing a newer message.
+	statusMsg   string
+	statusMsgID int
+
 	// Error state
 	err error
 }
 
-// initialModel creates the initial model.
-func initialModel(db *DB, stats *GlobalStats, rootPath, fileName string) model {
+// initialModel creates the initial model. diffDB is nil unless --baseline
+// was given. deepScan enables --deep-scan content sniffing as directories
+// are loaded.
+func initialModel(db *DB, stats *GlobalStats, rootPath, fileName string, diffDB *DiffDB, deepScan bool) model {
 	ti := textinput.New()
 	ti.Placeholder = "Search files..."
 	ti.CharLimit = 100
@@ -145,6 +210,13 @@ func initialModel(db *DB, stats *GlobalStats, rootPath, fileName string) model {
 	ti.PromptStyle = lipgloss.NewStyle().Foreground(colorWarning)
 	ti.TextStyle = lipgloss.NewStyle().Foreground(colorTextBright)
 
+	ei := textinput.New()
+	ei.Placeholder = "Export path (.sh, .json, or plain list)..."
+	ei.CharLimit = 500
+	ei.Width = 50
+	ei.PromptStyle = lipgloss.NewStyle().Foreground(colorWarning)
+	ei.TextStyle = lipgloss.NewStyle().Foreground(colorTextBright)
+
 	h := help.New()
 	h.ShowAll = false
 	h.Styles.ShortKey = lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
@@ -160,8 +232,11 @@ func initialModel(db *DB, stats *GlobalStats, rootPath, fileName string) model {
 		stack:       make([]string, 0),
 		stats:       stats,
 		searchInput: ti,
+		exportInput: ei,
 		help:        h,
 		fileName:    fileName,
+		diffDB:      diffDB,
+		deepScan:    deepScan,
 		width:       120,
 		height:      40,
 	}
@@ -185,7 +260,8 @@ func (m model) loadCurrentDirectory() tea.Msg {
 		return errMsg{err}
 	}
 
-	return childrenLoadedMsg{children: children, usage: usage}
+	mismatches := deepScanMismatches(m.deepScan, children)
+	return childrenLoadedMsg{children: children, usage: usage, mismatches: mismatches}
 }
 
 // getChildren returns children from cache or queries the database.
@@ -195,12 +271,25 @@ func (m *model) getChildren(path string) ([]*Node, error) {
 		return cached, nil
 	}
 
+	// A positive miss above doesn't distinguish "never queried" from
+	// "queried and confirmed empty" (both look like a nil slice); the
+	// negative cache does, so a file or a childless directory doesn't
+	// re-hit DuckDB on every redraw.
+	if m.cache.Negative(path) {
+		return nil, nil
+	}
+
 	// Query database
 	children, err := m.db.GetChildren(path)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(children) == 0 {
+		m.cache.SetNegative(path)
+		return nil, nil
+	}
+
 	// Cache the result
 	m.cache.Set(path, children)
 
@@ -209,49 +298,141 @@ func (m *model) getChildren(path string) ([]*Node, error) {
 
 // Message types
 type childrenLoadedMsg struct {
-	children []*Node
-	usage    int64
+	children   []*Node
+	usage      int64
+	mismatches []FileTypeMismatch
 }
 
 type flatListLoadedMsg struct {
-	files []*Node
+	files      []*Node
+	mismatches []FileTypeMismatch
 }
 
 type largestLoadedMsg struct {
-	files []*Node
+	files      []*Node
+	mismatches []FileTypeMismatch
 }
 
 type typeStatsLoadedMsg struct {
 	stats []FileTypeStat
 }
 
+type diffChildrenLoadedMsg struct {
+	children []*DiffNode
+}
+
 type errMsg struct {
 	err error
 }
 
+// loadDiffChildren loads ViewDiff rows for the current directory.
+func (m model) loadDiffChildren() tea.Msg {
+	children, err := m.diffDB.GetChildren(m.currentPath)
+	if err != nil {
+		return errMsg{err}
+	}
+	return diffChildrenLoadedMsg{children: children}
+}
+
+// loadForView returns the command that (re)loads directory-scoped data for
+// m's current view mode, after a navigation into or out of a directory.
+// It also cancels any prefetch batch still running for the directory
+// being left.
+func (m model) loadForView() tea.Cmd {
+	m.cache.CancelPrefetches()
+	if m.viewMode == ViewDiff {
+		return m.loadDiffChildren
+	}
+	// ViewTreemap and ViewLargest also read m.children/m.currentUsage.
+	return m.loadCurrentDirectory
+}
+
+// prefetchFanout is how many of a directory's largest subdirectories get
+// speculatively warmed in the cache when the directory is opened.
+const prefetchFanout = 5
+
+// prefetchChildrenCmd speculatively warms the cache for children's
+// largest subdirectories, since those are the likeliest to be descended
+// into next. applySort may have reordered children in place, so this
+// takes the leading directories of a copy sorted by usage descending.
+func (m model) prefetchChildrenCmd(children []*Node) tea.Cmd {
+	children = append([]*Node(nil), children...)
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Usage > children[j].Usage
+	})
+	return func() tea.Msg {
+		var paths []string
+		for _, n := range children {
+			if !n.IsDir {
+				continue
+			}
+			paths = append(paths, n.FullPath)
+			if len(paths) >= prefetchFanout {
+				break
+			}
+		}
+		if len(paths) == 0 {
+			return nil
+		}
+		m.cache.PrefetchChildren(context.Background(), paths, m.db.GetChildren)
+		return nil
+	}
+}
+
+// clearStatusMsg clears the status line set by setStatus, unless a newer
+// one has since replaced it.
+type clearStatusMsg struct {
+	id int
+}
+
+// setStatus returns a command that sets m's status message and schedules
+// it to clear after a few seconds.
+func (m *model) setStatus(text string) tea.Cmd {
+	m.statusMsgID++
+	id := m.statusMsgID
+	m.statusMsg = text
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+		return clearStatusMsg{id: id}
+	})
+}
+
 // Update is the Bubble Tea update function.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case clearStatusMsg:
+		if msg.id == m.statusMsgID {
+			m.statusMsg = ""
+		}
+		return m, nil
+
 	case childrenLoadedMsg:
 		m.children = msg.children
 		m.currentUsage = msg.usage
 		m.applySort()
-		return m, nil
+		m.recordMismatches(msg.mismatches)
+		return m, m.prefetchChildrenCmd(msg.children)
 
 	case flatListLoadedMsg:
 		m.flatList = msg.files
+		m.recordMismatches(msg.mismatches)
 		return m, nil
 
 	case largestLoadedMsg:
 		m.largestFiles = msg.files
+		m.recordMismatches(msg.mismatches)
 		return m, nil
 
 	case typeStatsLoadedMsg:
 		m.typeStats = msg.stats
 		return m, nil
 
+	case diffChildrenLoadedMsg:
+		m.diffChildren = msg.children
+		m.applyDiffSort()
+		return m, nil
+
 	case errMsg:
 		m.err = msg.err
 		return m, nil
@@ -273,6 +454,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchInput.SetValue("")
 				m.searchQuery = ""
 				m.filtered = nil
+				m.matches = nil
 			default:
 				m.searchInput, cmd = m.searchInput.Update(msg)
 				return m, cmd
@@ -281,6 +463,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Handle cart export path prompt
+	if m.exporting {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.String() {
+			case "enter":
+				m.exporting = false
+				path := m.exportInput.Value()
+				m.exportInput.SetValue("")
+				cmd := m.exportCart(path)
+				return m, cmd
+			case "esc":
+				m.exporting = false
+				m.exportInput.SetValue("")
+			default:
+				m.exportInput, cmd = m.exportInput.Update(msg)
+				return m, cmd
+			}
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
@@ -295,19 +499,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchQuery = ""
 				m.searchInput.SetValue("")
 				m.filtered = nil
+				m.matches = nil
 				m.cursor = 0
 				m.offset = 0
 			}
 
 		case key.Matches(msg, keys.Up):
-			if m.cursor > 0 {
+			if m.viewMode == ViewTreemap {
+				m.cursor = m.treemapMove(treemapUp)
+			} else if m.cursor > 0 {
 				m.cursor--
 				m.ensureVisible()
 			}
 
 		case key.Matches(msg, keys.Down):
-			maxCursor := m.getMaxCursor()
-			if m.cursor < maxCursor-1 {
+			if m.viewMode == ViewTreemap {
+				m.cursor = m.treemapMove(treemapDown)
+			} else if maxCursor := m.getMaxCursor(); m.cursor < maxCursor-1 {
 				m.cursor++
 				m.ensureVisible()
 			}
@@ -337,8 +545,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = m.getMaxCursor() - 1
 			m.ensureVisible()
 
-		case key.Matches(msg, keys.Enter), key.Matches(msg, keys.Right):
-			if m.viewMode == ViewTree || m.viewMode == ViewLargest {
+		case key.Matches(msg, keys.Right):
+			if m.viewMode == ViewTreemap {
+				m.cursor = m.treemapMove(treemapRight)
+				break
+			}
+			fallthrough
+
+		case key.Matches(msg, keys.Enter):
+			if m.viewMode == ViewTree || m.viewMode == ViewLargest || m.viewMode == ViewDiff || m.viewMode == ViewTreemap {
 				selected := m.getSelected()
 				if selected != nil && selected.IsDir {
 					m.stack = append(m.stack, m.currentPath)
@@ -346,23 +561,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.cursor = 0
 					m.offset = 0
 					m.filtered = nil
+					m.matches = nil
 					m.searchQuery = ""
 					m.searchInput.SetValue("")
-					return m, m.loadCurrentDirectory
+					return m, m.loadForView()
 				}
 			}
 
-		case key.Matches(msg, keys.Back), key.Matches(msg, keys.Left):
-			if m.viewMode == ViewTree {
+		case key.Matches(msg, keys.Left):
+			if m.viewMode == ViewTreemap {
+				m.cursor = m.treemapMove(treemapLeft)
+				break
+			}
+			fallthrough
+
+		case key.Matches(msg, keys.Back):
+			if m.viewMode == ViewTree || m.viewMode == ViewDiff || m.viewMode == ViewTreemap {
 				if len(m.stack) > 0 {
 					m.currentPath = m.stack[len(m.stack)-1]
 					m.stack = m.stack[:len(m.stack)-1]
 					m.cursor = 0
 					m.offset = 0
 					m.filtered = nil
+					m.matches = nil
 					m.searchQuery = ""
 					m.searchInput.SetValue("")
-					return m, m.loadCurrentDirectory
+					return m, m.loadForView()
 				}
 			}
 
@@ -373,7 +597,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.View):
 			oldMode := m.viewMode
-			m.viewMode = ViewMode((int(m.viewMode) + 1) % 4)
+			next := ViewMode((int(m.viewMode) + 1) % numViewModes)
+			if next == ViewDiff && m.diffDB == nil {
+				// No baseline loaded: skip straight past the diff view.
+				next = ViewMode((int(next) + 1) % numViewModes)
+			}
+			m.viewMode = next
 			m.cursor = 0
 			m.offset = 0
 
@@ -391,6 +620,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.typeStats == nil {
 					return m, m.loadTypeStats
 				}
+			case ViewDiff:
+				return m, m.loadDiffChildren
+			case ViewTreemap:
+				if m.children == nil {
+					return m, m.loadCurrentDirectory
+				}
 			case ViewTree:
 				if oldMode != ViewTree {
 					// Refresh tree view
@@ -399,10 +634,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, keys.Sort):
-			m.sortMode = SortMode((int(m.sortMode) + 1) % 4)
-			m.applySort()
+			if m.viewMode == ViewDiff {
+				m.diffSortMode = DiffSortMode((int(m.diffSortMode) + 1) % 4)
+				m.applyDiffSort()
+			} else {
+				m.sortMode = SortMode((int(m.sortMode) + 1) % 4)
+				m.applySort()
+			}
 			m.cursor = 0
 			m.offset = 0
+
+		case key.Matches(msg, keys.Yank):
+			cmd := m.yankSelectedPath()
+			return m, cmd
+
+		case key.Matches(msg, keys.YankAll):
+			cmd := m.yankCurrentListing()
+			return m, cmd
+
+		case key.Matches(msg, keys.Export):
+			cmd := m.exportCurrentView()
+			return m, cmd
+
+		case key.Matches(msg, keys.Cart):
+			cmd := m.toggleCart()
+			return m, cmd
+
+		case key.Matches(msg, keys.CartExport):
+			m.exporting = true
+			m.exportInput.Focus()
+			return m, textinput.Blink
 		}
 
 	case tea.WindowSizeMsg:
@@ -420,7 +681,7 @@ func (m model) loadFlatList() tea.Msg {
 	if err != nil {
 		return errMsg{err}
 	}
-	return flatListLoadedMsg{files: files}
+	return flatListLoadedMsg{files: files, mismatches: deepScanMismatches(m.deepScan, files)}
 }
 
 // loadLargestFiles loads the largest files.
@@ -429,7 +690,7 @@ func (m model) loadLargestFiles() tea.Msg {
 	if err != nil {
 		return errMsg{err}
 	}
-	return largestLoadedMsg{files: files}
+	return largestLoadedMsg{files: files, mismatches: deepScanMismatches(m.deepScan, files)}
 }
 
 // loadTypeStats loads file type statistics.
@@ -470,7 +731,16 @@ func (m model) getMaxCursor() int {
 	case ViewTypes:
 		return len(m.typeStats)
 	case ViewLargest:
+		if m.filtered != nil {
+			return len(m.filtered)
+		}
 		return len(m.largestFiles)
+	case ViewDiff:
+		return len(m.diffChildren)
+	case ViewTreemap:
+		return len(m.children)
+	case ViewCart:
+		return len(m.cart)
 	}
 	return 0
 }
@@ -494,38 +764,65 @@ func (m model) getSelected() *Node {
 			return list[m.cursor]
 		}
 	case ViewLargest:
-		if m.cursor >= 0 && m.cursor < len(m.largestFiles) {
-			return m.largestFiles[m.cursor]
+		list := m.largestFiles
+		if m.filtered != nil {
+			list = m.filtered
+		}
+		if m.cursor >= 0 && m.cursor < len(list) {
+			return list[m.cursor]
+		}
+	case ViewDiff:
+		if m.cursor >= 0 && m.cursor < len(m.diffChildren) {
+			return m.diffChildren[m.cursor].Node
+		}
+	case ViewTreemap:
+		cells := m.currentTreemapCells()
+		if m.cursor >= 0 && m.cursor < len(cells) {
+			return cells[m.cursor].Node
+		}
+	case ViewCart:
+		list := m.cartList()
+		if m.cursor >= 0 && m.cursor < len(list) {
+			return list[m.cursor]
 		}
 	}
 	return nil
 }
 
+// getSelectedDiff returns the DiffNode under the cursor in ViewDiff, or nil.
+func (m model) getSelectedDiff() *DiffNode {
+	if m.viewMode != ViewDiff || m.cursor < 0 || m.cursor >= len(m.diffChildren) {
+		return nil
+	}
+	return m.diffChildren[m.cursor]
+}
+
+// applySearch ranks the current view's nodes by fuzzy match score against
+// m.searchQuery. While a query is active, m.filtered is ordered by score
+// rather than m.sortMode.
 func (m *model) applySearch() {
 	if m.searchQuery == "" {
 		m.filtered = nil
+		m.matches = nil
 		return
 	}
 
-	query := strings.ToLower(m.searchQuery)
 	var source []*Node
-
 	switch m.viewMode {
 	case ViewTree:
 		source = m.children
-	case ViewFlat, ViewLargest:
+	case ViewFlat:
 		source = m.flatList
+	case ViewLargest:
+		source = m.largestFiles
 	default:
 		return
 	}
 
-	m.filtered = make([]*Node, 0)
-	for _, n := range source {
-		if strings.Contains(strings.ToLower(n.Name), query) ||
-			strings.Contains(strings.ToLower(n.FullPath), query) {
-			m.filtered = append(m.filtered, n)
-		}
-	}
+	mode := m.viewMode
+	m.filtered, m.matches = fuzzyFilter(source, m.searchQuery, func(n *Node) string {
+		return searchKey(mode, n)
+	})
 }
 
 func (m *model) applySort() {
@@ -561,6 +858,32 @@ func (m *model) applySort() {
 	}
 }
 
+// applyDiffSort reorders m.diffChildren per m.diffSortMode.
+func (m *model) applyDiffSort() {
+	if m.diffChildren == nil {
+		return
+	}
+
+	switch m.diffSortMode {
+	case DiffSortMagnitude:
+		sort.Slice(m.diffChildren, func(i, j int) bool {
+			return abs64(m.diffChildren[i].DeltaUsage) > abs64(m.diffChildren[j].DeltaUsage)
+		})
+	case DiffSortGainers:
+		sort.Slice(m.diffChildren, func(i, j int) bool {
+			return m.diffChildren[i].DeltaUsage > m.diffChildren[j].DeltaUsage
+		})
+	case DiffSortLosers:
+		sort.Slice(m.diffChildren, func(i, j int) bool {
+			return m.diffChildren[i].DeltaUsage < m.diffChildren[j].DeltaUsage
+		})
+	case DiffSortName:
+		sort.Slice(m.diffChildren, func(i, j int) bool {
+			return strings.ToLower(m.diffChildren[i].Name) < strings.ToLower(m.diffChildren[j].Name)
+		})
+	}
+}
+
 // View is the Bubble Tea view function.
 func (m model) View() string {
 	if m.width == 0 {
@@ -0,0 +1,199 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+)
+
+// RunMount serves db's audited tree as a read-only FUSE filesystem at
+// mountpoint until interrupted (Ctrl-C or the mount being unmounted from
+// elsewhere), letting existing shell tools (find, du, fzf) walk the audit
+// without touching the real disk.
+func RunMount(db *DB, mountpoint string) error {
+	c, err := fuse.Mount(mountpoint,
+		fuse.ReadOnly(),
+		fuse.FSName("gdu-view"),
+		fuse.Subtype("parquet"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mount %s: %w", mountpoint, err)
+	}
+	defer c.Close()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		fuse.Unmount(mountpoint)
+	}()
+
+	filesys := &auditFS{
+		db:        db,
+		cache:     NewDirCache(256),
+		missing:   newMissingCache(),
+		mountedAt: time.Now(),
+	}
+
+	if err := fs.Serve(c, filesys); err != nil {
+		return fmt.Errorf("fuse serve failed: %w", err)
+	}
+
+	return nil
+}
+
+// auditFS is the FUSE filesystem backed by a single DB's parquet snapshot.
+type auditFS struct {
+	db        *DB
+	cache     *DirCache
+	missing   *missingCache
+	mountedAt time.Time // stamped at mount time: the parquet has no per-node mtime
+}
+
+func (f *auditFS) Root() (fs.Node, error) {
+	rootPath, err := f.db.GetRootPath()
+	if err != nil {
+		return nil, err
+	}
+	return &auditDir{fs: f, path: rootPath}, nil
+}
+
+// auditDir is a directory node synthesized from rows with is_dir = true.
+type auditDir struct {
+	fs   *auditFS
+	path string
+}
+
+var _ fs.Node = (*auditDir)(nil)
+var _ fs.NodeStringLookuper = (*auditDir)(nil)
+var _ fs.HandleReadDirAller = (*auditDir)(nil)
+
+func (d *auditDir) Attr(ctx context.Context, a *fuse.Attr) error {
+	node, err := d.fs.db.GetNode(d.path)
+	if err != nil {
+		// The root row itself isn't always present (depth 0 may have been
+		// the walk's starting point rather than a scanned entry); fall
+		// back to a bare directory rather than failing the whole mount.
+		a.Mode = os.ModeDir | 0555
+		a.Mtime = d.fs.mountedAt
+		return nil
+	}
+
+	a.Mode = os.ModeDir | 0555
+	a.Size = uint64(node.Usage)
+	a.Nlink = uint32(node.ItemCount) + 2
+	a.Mtime = d.fs.mountedAt
+	return nil
+}
+
+func (d *auditDir) Lookup(ctx context.Context, name string) (fs.Node, error) {
+	if d.fs.missing.Has(d.path, name) {
+		return nil, syscall.ENOENT
+	}
+
+	for _, child := range d.children() {
+		if child.Name != name {
+			continue
+		}
+		if child.IsDir {
+			return &auditDir{fs: d.fs, path: child.FullPath}, nil
+		}
+		return &auditFile{fs: d.fs, node: child}, nil
+	}
+
+	d.fs.missing.Add(d.path, name)
+	return nil, syscall.ENOENT
+}
+
+func (d *auditDir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
+	children := d.children()
+	ents := make([]fuse.Dirent, 0, len(children))
+	for _, child := range children {
+		typ := fuse.DT_File
+		if child.IsDir {
+			typ = fuse.DT_Dir
+		}
+		ents = append(ents, fuse.Dirent{Name: child.Name, Type: typ})
+	}
+	return ents, nil
+}
+
+// children returns d's children from the shared DirCache, falling back to
+// the database and populating the cache on a miss.
+func (d *auditDir) children() []*Node {
+	if cached := d.fs.cache.Get(d.path); cached != nil {
+		return cached
+	}
+
+	children, err := d.fs.db.GetChildren(d.path)
+	if err != nil {
+		return nil
+	}
+
+	d.fs.cache.Set(d.path, children)
+	return children
+}
+
+// auditFile is a file leaf. The audit never captured file contents, so
+// reads return ENODATA; its stat size is still correct as of the
+// snapshot.
+type auditFile struct {
+	fs   *auditFS
+	node *Node
+}
+
+var _ fs.Node = (*auditFile)(nil)
+var _ fs.HandleReadAller = (*auditFile)(nil)
+
+func (f *auditFile) Attr(ctx context.Context, a *fuse.Attr) error {
+	a.Mode = 0444
+	a.Size = uint64(f.node.Usage)
+	a.Mtime = f.fs.mountedAt
+	return nil
+}
+
+func (f *auditFile) ReadAll(ctx context.Context) ([]byte, error) {
+	return nil, syscall.ENODATA
+}
+
+// missingCache remembers (dir, name) pairs that already resolved to
+// ENOENT, so repeated lookups for names that don't exist (shells commonly
+// probe for .hg/.git-style marker files on every cd) skip re-scanning the
+// cached children slice. This is deliberately simpler than the TTL'd
+// negative cache chunk 1-4 adds to DirCache itself: the parquet snapshot
+// is immutable for the lifetime of a mount, so a missing path never
+// becomes present and entries never need to expire.
+type missingCache struct {
+	mu    sync.RWMutex
+	items map[string]struct{}
+}
+
+func newMissingCache() *missingCache {
+	return &missingCache{items: make(map[string]struct{})}
+}
+
+func missingKey(dir, name string) string {
+	return dir + "\x00" + name
+}
+
+func (c *missingCache) Has(dir, name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.items[missingKey(dir, name)]
+	return ok
+}
+
+func (c *missingCache) Add(dir, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[missingKey(dir, name)] = struct{}{}
+}
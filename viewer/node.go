@@ -9,13 +9,23 @@ import (
 type Node struct {
 	Name      string
 	FullPath  string
-	Size      int64  // Logical size (asize)
-	Usage     int64  // Disk usage (dsize)
+	Size      int64 // Logical size (asize)
+	Usage     int64 // Disk usage (dsize)
 	IsDir     bool
 	FileType  string
 	Extension string
 	ItemCount int64 // For dirs: total files underneath
 	Depth     int
+
+	// Populated only by --sample-compress: sampled estimates of how well
+	// this file would compress under gzip and zstd. Compressibility and
+	// ZstdCompressibility are the compressed/original ratio (smaller means
+	// more compressible); all four fields are left at their zero value for
+	// directories and anything --sample-compress skipped.
+	CompressedEstimate     int64
+	Compressibility        float32
+	ZstdCompressedEstimate int64
+	ZstdCompressibility    float32
 }
 
 // FileTypeStat holds aggregated stats for a file type category.
@@ -25,68 +35,12 @@ type FileTypeStat struct {
 	Count int
 }
 
-// getFileType categorizes a file by its extension.
+// getFileType categorizes a file by name against activeTaxonomy, the
+// data-driven rule set --profile/--taxonomy resolve at startup (see
+// taxonomy.go). Defaults to the built-in dev-shop categorization when
+// neither flag was given.
 func getFileType(name string) string {
-	ext := strings.ToLower(filepath.Ext(name))
-
-	codeExts := map[string]bool{
-		".go": true, ".py": true, ".js": true, ".ts": true, ".jsx": true, ".tsx": true,
-		".c": true, ".cpp": true, ".h": true, ".hpp": true, ".rs": true, ".java": true,
-		".rb": true, ".php": true, ".swift": true, ".kt": true, ".scala": true,
-		".sh": true, ".bash": true, ".zsh": true, ".fish": true, ".ps1": true,
-		".sql": true, ".r": true, ".m": true, ".f90": true, ".jl": true,
-	}
-
-	dataExts := map[string]bool{
-		".csv": true, ".json": true, ".xml": true, ".yaml": true, ".yml": true,
-		".parquet": true, ".avro": true, ".orc": true, ".hdf5": true, ".h5": true,
-		".npy": true, ".npz": true, ".pkl": true, ".pickle": true, ".feather": true,
-		".db": true, ".sqlite": true, ".sqlite3": true, ".mdb": true,
-	}
-
-	mediaExts := map[string]bool{
-		".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".bmp": true,
-		".svg": true, ".webp": true, ".ico": true, ".tiff": true, ".psd": true,
-		".mp4": true, ".avi": true, ".mov": true, ".mkv": true, ".wmv": true,
-		".mp3": true, ".wav": true, ".flac": true, ".aac": true, ".ogg": true,
-		".webm": true, ".m4v": true, ".m4a": true,
-	}
-
-	archiveExts := map[string]bool{
-		".zip": true, ".tar": true, ".gz": true, ".bz2": true, ".xz": true,
-		".7z": true, ".rar": true, ".tgz": true, ".tbz2": true, ".lz4": true,
-		".zst": true, ".iso": true, ".dmg": true,
-	}
-
-	docExts := map[string]bool{
-		".pdf": true, ".doc": true, ".docx": true, ".xls": true, ".xlsx": true,
-		".ppt": true, ".pptx": true, ".odt": true, ".ods": true, ".odp": true,
-		".txt": true, ".md": true, ".rst": true, ".tex": true, ".rtf": true,
-		".epub": true, ".mobi": true,
-	}
-
-	configExts := map[string]bool{
-		".toml": true, ".ini": true, ".cfg": true, ".conf": true, ".env": true,
-		".gitignore": true, ".dockerignore": true, ".editorconfig": true,
-		".htaccess": true, ".properties": true,
-	}
-
-	switch {
-	case codeExts[ext]:
-		return "code"
-	case dataExts[ext]:
-		return "data"
-	case mediaExts[ext]:
-		return "media"
-	case archiveExts[ext]:
-		return "archive"
-	case docExts[ext]:
-		return "doc"
-	case configExts[ext]:
-		return "config"
-	default:
-		return "other"
-	}
+	return activeTaxonomy.Classify(name)
 }
 
 // getExtension returns the lowercase extension of a filename.
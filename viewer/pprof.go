@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+)
+
+// pprofEnvVar enables CPU/heap profiling for this run when set: either to
+// "1" to write into the working directory, or to a directory path to
+// write the profiles into instead. DirCache, the lazy view loaders, and
+// applySort are the usual hotspots on large parquet files.
+const pprofEnvVar = "GDU_VIEW_PPROF"
+
+// startPprof starts CPU profiling to cpu.prof when GDU_VIEW_PPROF is set,
+// and returns a func that stops CPU profiling and writes mem.prof. Both
+// are no-ops, at effectively zero cost, when the env var is unset.
+func startPprof() func() {
+	val := os.Getenv(pprofEnvVar)
+	if val == "" {
+		return func() {}
+	}
+
+	dir := val
+	if dir == "1" {
+		dir = "."
+	}
+
+	cpuPath := dir + "/cpu.prof"
+	cpuFile, err := os.Create(cpuPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pprof: failed to create %s: %v\n", cpuPath, err)
+		return func() {}
+	}
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		fmt.Fprintf(os.Stderr, "pprof: failed to start CPU profile: %v\n", err)
+		cpuFile.Close()
+		return func() {}
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+
+		memPath := dir + "/mem.prof"
+		memFile, err := os.Create(memPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "pprof: failed to create %s: %v\n", memPath, err)
+			return
+		}
+		defer memFile.Close()
+
+		if err := pprof.WriteHeapProfile(memFile); err != nil {
+			fmt.Fprintf(os.Stderr, "pprof: failed to write heap profile: %v\n", err)
+		}
+	}
+}
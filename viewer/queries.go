@@ -381,6 +381,56 @@ func (db *DB) GetCurrentDirStats(path string) (size int64, itemCount int, err er
 	return size, int(ic), nil
 }
 
+// QueryNodes returns every row (file or directory) matching the given SQL
+// WHERE clause (without the "WHERE" keyword; pass "" to match everything),
+// for use by the expr reducer, which applies any predicate it couldn't
+// push down (e.g. on the computed "type" field) itself afterwards.
+func (db *DB) QueryNodes(whereClause string, args []any) ([]*Node, error) {
+	query := fmt.Sprintf(`
+		SELECT path, name, size, usage, is_dir, item_count, depth
+		FROM '%s'
+	`, db.parquetPath)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []*Node
+	for rows.Next() {
+		var node Node
+		var itemCount int64
+		err := rows.Scan(
+			&node.FullPath,
+			&node.Name,
+			&node.Size,
+			&node.Usage,
+			&node.IsDir,
+			&itemCount,
+			&node.Depth,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		node.ItemCount = itemCount
+		if !node.IsDir {
+			node.FileType = getFileType(node.Name)
+			node.Extension = getExtension(node.Name)
+		} else {
+			node.FileType = "dir"
+		}
+
+		nodes = append(nodes, &node)
+	}
+
+	return nodes, nil
+}
+
 // pathExt extracts the file extension from a path.
 func pathExt(path string) string {
 	return filepath.Ext(path)
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	goyaml "github.com/goccy/go-yaml"
+
+	"github.com/michaelscutari/lab_audit/viewer/expr"
+)
+
+// stdoutIsTTY reports whether stdout looks like an interactive terminal.
+// When it isn't (e.g. piped into jq or a file), gdu-view drops into
+// reducer mode instead of starting the TUI.
+func stdoutIsTTY() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// RunReducer evaluates exprSrc against every node in db and writes the
+// result to w as JSON or YAML, turning gdu-view into a composable CLI
+// alongside its TUI mode. An empty exprSrc matches and returns every node.
+func RunReducer(db *DB, exprSrc, output string, w io.Writer) error {
+	q, err := expr.Parse(exprSrc)
+	if err != nil {
+		return fmt.Errorf("failed to parse expression: %w", err)
+	}
+
+	where, args, pushedDown := expr.CompileWhere(q.Filter)
+	if !pushedDown {
+		// The predicate references a field (e.g. "type") that isn't a
+		// parquet column; fetch everything and let expr.Eval apply the
+		// full predicate in memory below.
+		where, args = "", nil
+	}
+
+	nodes, err := db.QueryNodes(where, args)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes: %w", err)
+	}
+
+	records := make([]expr.Record, len(nodes))
+	for i, n := range nodes {
+		records[i] = nodeRecord(n)
+	}
+
+	// Re-run the full predicate in memory whenever it couldn't be pushed
+	// down, since the SQL query above didn't filter on it at all.
+	evalQuery := q
+	if pushedDown {
+		evalQuery = &expr.Query{Pipeline: q.Pipeline}
+	}
+	results, err := expr.Eval(records, evalQuery)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+
+	switch output {
+	case "", "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case "yaml":
+		out, err := goyaml.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to marshal yaml: %w", err)
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return fmt.Errorf("unknown --output format %q (want json or yaml)", output)
+	}
+}
+
+// nodeRecord converts a Node to the expr.Record shape its field selectors
+// (.name, .path, .size, .type, .is_dir, .item_count) address.
+func nodeRecord(n *Node) expr.Record {
+	return expr.Record{
+		"name":       n.Name,
+		"path":       n.FullPath,
+		"size":       n.Size,
+		"usage":      n.Usage,
+		"type":       n.FileType,
+		"is_dir":     n.IsDir,
+		"item_count": n.ItemCount,
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import "github.com/sahilm/fuzzy"
+
+// fuzzyFilter ranks nodes by fuzzy match score against the string extracted
+// by key, returning the matching nodes in score order along with each
+// match's rune indexes into its extracted key (for highlighting). Returns
+// nil, nil when query is empty.
+func fuzzyFilter(nodes []*Node, query string, key func(*Node) string) ([]*Node, [][]int) {
+	if query == "" {
+		return nil, nil
+	}
+
+	targets := make([]string, len(nodes))
+	for i, n := range nodes {
+		targets[i] = key(n)
+	}
+
+	matches := fuzzy.Find(query, targets)
+
+	filtered := make([]*Node, len(matches))
+	indexes := make([][]int, len(matches))
+	for i, match := range matches {
+		filtered[i] = nodes[match.Index]
+		// fuzzy reports byte offsets; convert them to rune offsets.
+		runeAt := make(map[int]int, len(match.Str))
+		r := 0
+		for b := range match.Str {
+			runeAt[b] = r
+			r++
+		}
+		indexes[i] = make([]int, len(match.MatchedIndexes))
+		for k, b := range match.MatchedIndexes {
+			indexes[i][k] = runeAt[b]
+		}
+	}
+
+	return filtered, indexes
+}
+
+// searchKey returns the string fuzzy-matched against for the given view
+// mode: the node's bare name in tree view, its full path everywhere else.
+func searchKey(mode ViewMode, n *Node) string {
+	if mode == ViewTree {
+		return n.Name
+	}
+	return n.FullPath
+}
+
+// nameRelativeMatches converts indexes (rune offsets into searchKey(mode, n))
+// to offsets into n.Name, so renderNodeList can highlight matches even in
+// views whose searchKey is FullPath rather than Name. n.Name is FullPath's
+// suffix, so this just shifts each index left by the rune length of the
+// path prefix FullPath has beyond Name, dropping any index that lands
+// before it (i.e. a match inside the path rather than the filename itself).
+func nameRelativeMatches(mode ViewMode, n *Node, indexes []int) []int {
+	if mode == ViewTree {
+		return indexes
+	}
+
+	offset := len([]rune(n.FullPath)) - len([]rune(n.Name))
+	relative := make([]int, 0, len(indexes))
+	for _, idx := range indexes {
+		if idx >= offset {
+			relative = append(relative, idx-offset)
+		}
+	}
+	return relative
+}
@@ -0,0 +1,81 @@
+package main
+
+import "testing"
+
+func testNodes() []*Node {
+	return []*Node{
+		{Name: "readme.md", FullPath: "/root/readme.md"},
+		{Name: "main.go", FullPath: "/root/src/main.go"},
+		{Name: "résumé.pdf", FullPath: "/root/docs/résumé.pdf"},
+		{Name: "Dockerfile", FullPath: "/root/Dockerfile"},
+	}
+}
+
+func TestFuzzyFilterEmptyQuery(t *testing.T) {
+	filtered, matches := fuzzyFilter(testNodes(), "", func(n *Node) string { return n.Name })
+	if filtered != nil || matches != nil {
+		t.Fatalf("expected nil, nil for empty query, got %v, %v", filtered, matches)
+	}
+}
+
+func TestFuzzyFilterSkippedCharacters(t *testing.T) {
+	// sahilm/fuzzy is a subsequence matcher: it tolerates a query with
+	// characters missing (as long as what's left still appears in order),
+	// not a transposition, since that breaks subsequence order.
+	filtered, _ := fuzzyFilter(testNodes(), "man.go", func(n *Node) string { return n.Name })
+	if len(filtered) == 0 || filtered[0].Name != "main.go" {
+		t.Fatalf("expected main.go to match a query with a dropped character, got %v", filtered)
+	}
+}
+
+func TestFuzzyFilterCaseInsensitive(t *testing.T) {
+	filtered, _ := fuzzyFilter(testNodes(), "DOCKER", func(n *Node) string { return n.Name })
+	if len(filtered) == 0 || filtered[0].Name != "Dockerfile" {
+		t.Fatalf("expected case-insensitive match on Dockerfile, got %v", filtered)
+	}
+}
+
+func TestFuzzyFilterIndexAlignmentMultiByte(t *testing.T) {
+	nodes := testNodes()
+	query := "résumé"
+	filtered, matches := fuzzyFilter(nodes, query, func(n *Node) string { return n.Name })
+
+	idx := -1
+	for i, n := range filtered {
+		if n.Name == "résumé.pdf" {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		t.Fatalf("expected résumé.pdf to match query %q, got %v", query, filtered)
+	}
+
+	// Indexes must be rune positions, not byte offsets: "é" is two bytes
+	// but one rune, so byte offsets (0,1,3,4,5,6) would point every rune
+	// after the first "é" one position too far, and the runes at the
+	// matched indexes would no longer spell the query.
+	runes := []rune(filtered[idx].Name)
+	queryRunes := []rune(query)
+	if len(matches[idx]) != len(queryRunes) {
+		t.Fatalf("expected %d matched indexes, got %v", len(queryRunes), matches[idx])
+	}
+	for k, matchIdx := range matches[idx] {
+		if matchIdx < 0 || matchIdx >= len(runes) {
+			t.Fatalf("matched index %d out of rune range for %q (rune len %d)", matchIdx, filtered[idx].Name, len(runes))
+		}
+		if runes[matchIdx] != queryRunes[k] {
+			t.Fatalf("matched index %d is %q in %q, want %q", matchIdx, runes[matchIdx], filtered[idx].Name, queryRunes[k])
+		}
+	}
+}
+
+func TestFuzzyFilterScoreOrder(t *testing.T) {
+	nodes := []*Node{
+		{Name: "aXbXcXdXeXfX"},
+		{Name: "abcdef"},
+	}
+	filtered, _ := fuzzyFilter(nodes, "abcdef", func(n *Node) string { return n.Name })
+	if len(filtered) != 2 || filtered[0].Name != "abcdef" {
+		t.Fatalf("expected exact-ish match ranked first, got %v", filtered)
+	}
+}
@@ -2,94 +2,164 @@ package main
 
 import "github.com/charmbracelet/lipgloss"
 
-// Color Palette - Industrial Cyberpunk
+// Theme holds the full color palette for the TUI. A Theme is resolved once
+// at startup (see resolveTheme) from the --theme flag, the GDU_VIEW_THEME
+// env var, or a terminal-capability fallback, and applied via applyTheme.
+type Theme struct {
+	Name string
+
+	Bg           lipgloss.Color
+	BgAlt        lipgloss.Color
+	BgHighlight  lipgloss.Color
+	Border       lipgloss.Color
+	BorderBright lipgloss.Color
+	Text         lipgloss.Color
+	TextDim      lipgloss.Color
+	TextBright   lipgloss.Color
+	Accent       lipgloss.Color
+	AccentBright lipgloss.Color
+	Success      lipgloss.Color
+	Warning      lipgloss.Color
+	Danger       lipgloss.Color
+	Purple       lipgloss.Color
+	Cyan         lipgloss.Color
+	Orange       lipgloss.Color
+	Pink         lipgloss.Color
+
+	// FileTypes maps a Node.FileType category ("code", "data", "dir", ...)
+	// to its display color.
+	FileTypes map[string]lipgloss.Color
+}
+
+// activeTheme is the currently applied palette, set by applyTheme.
+var activeTheme Theme
+
+// Active palette, mirrored from activeTheme by applyTheme. Render code
+// throughout the package reads these package vars rather than a Theme
+// value directly, so a single applyTheme call re-skins every call site.
+var (
+	colorBg           lipgloss.Color
+	colorBgAlt        lipgloss.Color
+	colorBgHighlight  lipgloss.Color
+	colorBorder       lipgloss.Color
+	colorBorderBright lipgloss.Color
+	colorText         lipgloss.Color
+	colorTextDim      lipgloss.Color
+	colorTextBright   lipgloss.Color
+	colorAccent       lipgloss.Color
+	colorAccentBright lipgloss.Color
+	colorSuccess      lipgloss.Color
+	colorWarning      lipgloss.Color
+	colorDanger       lipgloss.Color
+	colorPurple       lipgloss.Color
+	colorCyan         lipgloss.Color
+	colorOrange       lipgloss.Color
+	colorPink         lipgloss.Color
+
+	// fileTypeColors maps a FileType category to its themed color.
+	fileTypeColors map[string]lipgloss.Color
+)
+
+// Styles, derived from the active theme by applyTheme.
 var (
-	colorBg           = lipgloss.Color("#0d1117")
-	colorBgAlt        = lipgloss.Color("#161b22")
-	colorBgHighlight  = lipgloss.Color("#21262d")
-	colorBorder       = lipgloss.Color("#30363d")
-	colorBorderBright = lipgloss.Color("#484f58")
-	colorText         = lipgloss.Color("#c9d1d9")
-	colorTextDim      = lipgloss.Color("#8b949e")
-	colorTextBright   = lipgloss.Color("#f0f6fc")
-	colorAccent       = lipgloss.Color("#58a6ff")
-	colorAccentBright = lipgloss.Color("#79c0ff")
-	colorSuccess      = lipgloss.Color("#3fb950")
-	colorWarning      = lipgloss.Color("#d29922")
-	colorDanger       = lipgloss.Color("#f85149")
-	colorPurple       = lipgloss.Color("#bc8cff")
-	colorCyan         = lipgloss.Color("#39c5cf")
-	colorOrange       = lipgloss.Color("#ffa657")
-	colorPink         = lipgloss.Color("#ff7b72")
+	titleStyle            lipgloss.Style
+	subtitleStyle         lipgloss.Style
+	breadcrumbStyle       lipgloss.Style
+	breadcrumbActiveStyle lipgloss.Style
+	statLabelStyle        lipgloss.Style
+	statValueStyle        lipgloss.Style
+	selectedStyle         lipgloss.Style
+	normalStyle           lipgloss.Style
+	dimStyle              lipgloss.Style
+	panelStyle            lipgloss.Style
+	activePanelStyle      lipgloss.Style
+	helpStyle             lipgloss.Style
+	searchStyle           lipgloss.Style
 )
 
-// File Type Colors
-var fileTypeColors = map[string]lipgloss.Color{
-	"code":    colorSuccess,
-	"data":    colorAccent,
-	"media":   colorPurple,
-	"archive": colorOrange,
-	"doc":     colorCyan,
-	"config":  colorWarning,
-	"other":   colorTextDim,
-	"dir":     colorAccentBright,
+func init() {
+	applyTheme(defaultTheme)
 }
 
-// Styles
-var (
+// applyTheme re-skins every package color and style from t. main calls it
+// once, after resolving the theme from flags/env and before the TUI
+// starts; init seeds the default theme so the zero-configuration case
+// (and tests) still get a usable palette.
+func applyTheme(t Theme) {
+	activeTheme = t
+
+	colorBg = t.Bg
+	colorBgAlt = t.BgAlt
+	colorBgHighlight = t.BgHighlight
+	colorBorder = t.Border
+	colorBorderBright = t.BorderBright
+	colorText = t.Text
+	colorTextDim = t.TextDim
+	colorTextBright = t.TextBright
+	colorAccent = t.Accent
+	colorAccentBright = t.AccentBright
+	colorSuccess = t.Success
+	colorWarning = t.Warning
+	colorDanger = t.Danger
+	colorPurple = t.Purple
+	colorCyan = t.Cyan
+	colorOrange = t.Orange
+	colorPink = t.Pink
+	fileTypeColors = t.FileTypes
+
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorTextBright).
-			Background(colorAccent).
-			Padding(0, 2)
+		Bold(true).
+		Foreground(colorTextBright).
+		Background(colorAccent).
+		Padding(0, 2)
 
 	subtitleStyle = lipgloss.NewStyle().
-			Foreground(colorAccent).
-			Bold(true)
+		Foreground(colorAccent).
+		Bold(true)
 
 	breadcrumbStyle = lipgloss.NewStyle().
-			Foreground(colorTextDim)
+		Foreground(colorTextDim)
 
 	breadcrumbActiveStyle = lipgloss.NewStyle().
-				Foreground(colorAccent).
-				Bold(true)
+		Foreground(colorAccent).
+		Bold(true)
 
 	statLabelStyle = lipgloss.NewStyle().
-			Foreground(colorTextDim)
+		Foreground(colorTextDim)
 
 	statValueStyle = lipgloss.NewStyle().
-			Foreground(colorTextBright).
-			Bold(true)
+		Foreground(colorTextBright).
+		Bold(true)
 
 	selectedStyle = lipgloss.NewStyle().
-			Background(colorBgHighlight).
-			Foreground(colorTextBright).
-			Bold(true)
+		Background(colorBgHighlight).
+		Foreground(colorTextBright).
+		Bold(true)
 
 	normalStyle = lipgloss.NewStyle().
-			Foreground(colorText)
+		Foreground(colorText)
 
 	dimStyle = lipgloss.NewStyle().
-			Foreground(colorTextDim)
+		Foreground(colorTextDim)
 
 	panelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(1, 2)
 
 	activePanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(colorAccent).
-				Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(colorTextDim)
+		Foreground(colorTextDim)
 
 	searchStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorWarning).
-			Padding(0, 1)
-)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorWarning).
+		Padding(0, 1)
+}
 
 // Bar Characters
 const (
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	goyaml "github.com/goccy/go-yaml"
+)
+
+// TaxonomyRule matches a file by extension, a case-insensitive glob over
+// its base name, or a whole filename compared case-insensitively (for
+// extensionless conventions like Makefile/Dockerfile), and assigns it to
+// Category.
+type TaxonomyRule struct {
+	Category string   `yaml:"category"`
+	Ext      []string `yaml:"ext,omitempty"`
+	Glob     []string `yaml:"glob,omitempty"`
+	Name     []string `yaml:"name,omitempty"`
+}
+
+// Taxonomy is an ordered set of rules getFileType consults, first match
+// wins, falling back to "other". Rules earlier in the slice take
+// priority, so a profile or user config is expected to prepend its rules
+// ahead of the defaults rather than replace them.
+type Taxonomy struct {
+	Name  string         `yaml:"name,omitempty"`
+	Rules []TaxonomyRule `yaml:"rules"`
+}
+
+// Classify returns the category the first rule matching name assigns, or
+// "other" if nothing matches.
+func (t Taxonomy) Classify(name string) string {
+	lower := strings.ToLower(name)
+	ext := strings.ToLower(filepath.Ext(name))
+
+	for _, rule := range t.Rules {
+		for _, n := range rule.Name {
+			if strings.EqualFold(n, name) {
+				return rule.Category
+			}
+		}
+		if ext != "" {
+			for _, e := range rule.Ext {
+				if ext == e {
+					return rule.Category
+				}
+			}
+		}
+		for _, g := range rule.Glob {
+			if ok, _ := filepath.Match(strings.ToLower(g), lower); ok {
+				return rule.Category
+			}
+		}
+	}
+	return "other"
+}
+
+// extensions collects every extension Classify would recognize, for
+// AutoDetectProfile to score a directory's contents against.
+func (t Taxonomy) extensions() map[string]bool {
+	exts := make(map[string]bool)
+	for _, rule := range t.Rules {
+		for _, e := range rule.Ext {
+			exts[e] = true
+		}
+	}
+	return exts
+}
+
+// merge returns a new Taxonomy whose rules are extra's followed by t's,
+// so extra takes priority without discarding t's fallback categories.
+func (t Taxonomy) merge(extra Taxonomy) Taxonomy {
+	rules := make([]TaxonomyRule, 0, len(extra.Rules)+len(t.Rules))
+	rules = append(rules, extra.Rules...)
+	rules = append(rules, t.Rules...)
+	return Taxonomy{Name: t.Name, Rules: rules}
+}
+
+// defaultTaxonomy reproduces the generic dev-shop extension categorization
+// this tool shipped with before --profile/--taxonomy existed, plus a
+// filename rule classing Makefile, Dockerfile and Snakefile as config.
+var defaultTaxonomy = Taxonomy{
+	Name: "default",
+	Rules: []TaxonomyRule{
+		{Category: "code", Ext: []string{
+			".go", ".py", ".js", ".ts", ".jsx", ".tsx",
+			".c", ".cpp", ".h", ".hpp", ".rs", ".java",
+			".rb", ".php", ".swift", ".kt", ".scala",
+			".sh", ".bash", ".zsh", ".fish", ".ps1",
+			".sql", ".r", ".m", ".f90", ".jl",
+		}},
+		{Category: "data", Ext: []string{
+			".csv", ".json", ".xml", ".yaml", ".yml",
+			".parquet", ".avro", ".orc", ".hdf5", ".h5",
+			".npy", ".npz", ".pkl", ".pickle", ".feather",
+			".db", ".sqlite", ".sqlite3", ".mdb",
+		}},
+		{Category: "media", Ext: []string{
+			".jpg", ".jpeg", ".png", ".gif", ".bmp",
+			".svg", ".webp", ".ico", ".tiff", ".psd",
+			".mp4", ".avi", ".mov", ".mkv", ".wmv",
+			".mp3", ".wav", ".flac", ".aac", ".ogg",
+			".webm", ".m4v", ".m4a",
+		}},
+		{Category: "archive", Ext: []string{
+			".zip", ".tar", ".gz", ".bz2", ".xz",
+			".7z", ".rar", ".tgz", ".tbz2", ".lz4",
+			".zst", ".iso", ".dmg",
+		}},
+		{Category: "doc", Ext: []string{
+			".pdf", ".doc", ".docx", ".xls", ".xlsx",
+			".ppt", ".pptx", ".odt", ".ods", ".odp",
+			".txt", ".md", ".rst", ".tex", ".rtf",
+			".epub", ".mobi",
+		}},
+		{Category: "config", Ext: []string{
+			".toml", ".ini", ".cfg", ".conf", ".env",
+			".gitignore", ".dockerignore", ".editorconfig",
+			".htaccess", ".properties",
+		}},
+		{Category: "config", Name: []string{"Makefile", "Dockerfile", "Snakefile"}},
+	},
+}
+
+// profiles is the registry of named domain profiles selectable via
+// --profile or AutoDetectProfile. Each profile's rules are layered ahead
+// of defaultTaxonomy's by ResolveTaxonomy, not in place of them.
+var profiles = map[string]Taxonomy{
+	"bioinformatics": {
+		Name: "bioinformatics",
+		Rules: []TaxonomyRule{
+			{Category: "bioinformatics", Ext: []string{
+				".fastq", ".fq", ".bam", ".sam", ".vcf", ".bed", ".gff", ".fasta",
+			}},
+		},
+	},
+	"imaging": {
+		Name: "imaging",
+		Rules: []TaxonomyRule{
+			{Category: "imaging", Ext: []string{".dcm", ".nii", ".czi", ".tif", ".tiff"}},
+		},
+	},
+	"ml": {
+		Name: "ml",
+		Rules: []TaxonomyRule{
+			{Category: "ml", Ext: []string{".ckpt", ".safetensors", ".onnx", ".pt", ".pth"}},
+		},
+	},
+	"notebook": {
+		Name: "notebook",
+		Rules: []TaxonomyRule{
+			{Category: "notebook", Ext: []string{".ipynb", ".rmd", ".qmd"}},
+		},
+	},
+}
+
+// profileNames returns the profiles registry's keys in a fixed, stable
+// order for --profile's usage text and error messages.
+func profileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTaxonomyFile reads a user-authored YAML taxonomy (a mapping in the
+// shape of Taxonomy: an optional name and a rules list of TaxonomyRule
+// entries) from path. Its rules take priority over
+// both the active profile's and the default's.
+func LoadTaxonomyFile(path string) (Taxonomy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Taxonomy{}, fmt.Errorf("failed to read taxonomy file: %w", err)
+	}
+
+	var t Taxonomy
+	if err := goyaml.Unmarshal(data, &t); err != nil {
+		return Taxonomy{}, fmt.Errorf("failed to parse taxonomy file: %w", err)
+	}
+	return t, nil
+}
+
+// AutoDetectProfile scores each named profile against extStats (typically
+// db.GetExtensionStats's output) by how many of its extensions actually
+// appear in the audit, and returns the best-scoring profile's name, or ""
+// if nothing scored above zero.
+func AutoDetectProfile(extStats []FileTypeStat) string {
+	best, bestScore := "", 0
+	for name, profile := range profiles {
+		exts := profile.extensions()
+		score := 0
+		for _, stat := range extStats {
+			if exts[strings.ToLower(stat.Type)] {
+				score += stat.Count
+			}
+		}
+		if score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+// ResolveTaxonomy builds the active Taxonomy for a run: defaultTaxonomy,
+// optionally layered with a named profile (resolved from the literal
+// --profile name, or auto-detected from db's extension stats when
+// profileFlag is "auto"), optionally layered again with a user YAML file
+// from --taxonomy. Layering order, highest priority first: taxonomyPath,
+// then the profile, then the default.
+func ResolveTaxonomy(profileFlag, taxonomyPath string, db *DB) (Taxonomy, error) {
+	result := defaultTaxonomy
+
+	profileName := profileFlag
+	if profileName == "auto" {
+		extStats, err := db.GetExtensionStats(1000)
+		if err == nil {
+			profileName = AutoDetectProfile(extStats)
+		} else {
+			profileName = ""
+		}
+	}
+	if profileName != "" {
+		profile, ok := profiles[profileName]
+		if !ok {
+			return Taxonomy{}, fmt.Errorf("unknown profile %q (available: %s)",
+				profileName, strings.Join(profileNames(), ", "))
+		}
+		result = result.merge(profile)
+	}
+
+	if taxonomyPath != "" {
+		user, err := LoadTaxonomyFile(taxonomyPath)
+		if err != nil {
+			return Taxonomy{}, err
+		}
+		result = result.merge(user)
+	}
+
+	return result, nil
+}
+
+// activeTaxonomy is the taxonomy getFileType consults, set by
+// applyTaxonomy. Defaults to defaultTaxonomy so callers that never touch
+// --profile/--taxonomy (tests, other entry points) still classify files.
+var activeTaxonomy = defaultTaxonomy
+
+// applyTaxonomy sets the taxonomy getFileType consults for the rest of
+// this run, mirroring applyTheme's package-global re-skin pattern.
+func applyTaxonomy(t Taxonomy) {
+	activeTaxonomy = t
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// themeEnvVar selects a built-in theme by name, overridden by --theme.
+const themeEnvVar = "GDU_VIEW_THEME"
+
+// defaultTheme is "industrial cyberpunk", the tool's original look.
+var defaultTheme = Theme{
+	Name:         "cyberpunk",
+	Bg:           lipgloss.Color("#0d1117"),
+	BgAlt:        lipgloss.Color("#161b22"),
+	BgHighlight:  lipgloss.Color("#21262d"),
+	Border:       lipgloss.Color("#30363d"),
+	BorderBright: lipgloss.Color("#484f58"),
+	Text:         lipgloss.Color("#c9d1d9"),
+	TextDim:      lipgloss.Color("#8b949e"),
+	TextBright:   lipgloss.Color("#f0f6fc"),
+	Accent:       lipgloss.Color("#58a6ff"),
+	AccentBright: lipgloss.Color("#79c0ff"),
+	Success:      lipgloss.Color("#3fb950"),
+	Warning:      lipgloss.Color("#d29922"),
+	Danger:       lipgloss.Color("#f85149"),
+	Purple:       lipgloss.Color("#bc8cff"),
+	Cyan:         lipgloss.Color("#39c5cf"),
+	Orange:       lipgloss.Color("#ffa657"),
+	Pink:         lipgloss.Color("#ff7b72"),
+	FileTypes: map[string]lipgloss.Color{
+		"code":    lipgloss.Color("#3fb950"),
+		"data":    lipgloss.Color("#58a6ff"),
+		"media":   lipgloss.Color("#bc8cff"),
+		"archive": lipgloss.Color("#ffa657"),
+		"doc":     lipgloss.Color("#39c5cf"),
+		"config":  lipgloss.Color("#d29922"),
+		"other":   lipgloss.Color("#8b949e"),
+		"dir":     lipgloss.Color("#79c0ff"),
+	},
+}
+
+// lightTheme is a light-background palette for daytime/white terminals.
+var lightTheme = Theme{
+	Name:         "light",
+	Bg:           lipgloss.Color("#ffffff"),
+	BgAlt:        lipgloss.Color("#f6f8fa"),
+	BgHighlight:  lipgloss.Color("#eaeef2"),
+	Border:       lipgloss.Color("#d0d7de"),
+	BorderBright: lipgloss.Color("#8c959f"),
+	Text:         lipgloss.Color("#24292f"),
+	TextDim:      lipgloss.Color("#57606a"),
+	TextBright:   lipgloss.Color("#0d1117"),
+	Accent:       lipgloss.Color("#0969da"),
+	AccentBright: lipgloss.Color("#218bff"),
+	Success:      lipgloss.Color("#1a7f37"),
+	Warning:      lipgloss.Color("#9a6700"),
+	Danger:       lipgloss.Color("#cf222e"),
+	Purple:       lipgloss.Color("#8250df"),
+	Cyan:         lipgloss.Color("#1b7c83"),
+	Orange:       lipgloss.Color("#bc4c00"),
+	Pink:         lipgloss.Color("#bf3989"),
+	FileTypes: map[string]lipgloss.Color{
+		"code":    lipgloss.Color("#1a7f37"),
+		"data":    lipgloss.Color("#0969da"),
+		"media":   lipgloss.Color("#8250df"),
+		"archive": lipgloss.Color("#bc4c00"),
+		"doc":     lipgloss.Color("#1b7c83"),
+		"config":  lipgloss.Color("#9a6700"),
+		"other":   lipgloss.Color("#57606a"),
+		"dir":     lipgloss.Color("#218bff"),
+	},
+}
+
+// asciiTheme sticks to the 16-color ANSI palette for terminals that can't
+// render truecolor/256-color output (termenv.Ascii).
+var asciiTheme = Theme{
+	Name:         "ascii",
+	Bg:           lipgloss.Color("0"),
+	BgAlt:        lipgloss.Color("0"),
+	BgHighlight:  lipgloss.Color("8"),
+	Border:       lipgloss.Color("7"),
+	BorderBright: lipgloss.Color("15"),
+	Text:         lipgloss.Color("7"),
+	TextDim:      lipgloss.Color("8"),
+	TextBright:   lipgloss.Color("15"),
+	Accent:       lipgloss.Color("14"),
+	AccentBright: lipgloss.Color("15"),
+	Success:      lipgloss.Color("10"),
+	Warning:      lipgloss.Color("11"),
+	Danger:       lipgloss.Color("9"),
+	Purple:       lipgloss.Color("13"),
+	Cyan:         lipgloss.Color("14"),
+	Orange:       lipgloss.Color("11"),
+	Pink:         lipgloss.Color("13"),
+	FileTypes: map[string]lipgloss.Color{
+		"code":    lipgloss.Color("10"),
+		"data":    lipgloss.Color("14"),
+		"media":   lipgloss.Color("13"),
+		"archive": lipgloss.Color("11"),
+		"doc":     lipgloss.Color("14"),
+		"config":  lipgloss.Color("11"),
+		"other":   lipgloss.Color("8"),
+		"dir":     lipgloss.Color("15"),
+	},
+}
+
+// themes is the registry of built-in themes, keyed by the name passed to
+// --theme / GDU_VIEW_THEME.
+var themes = map[string]Theme{
+	defaultTheme.Name: defaultTheme,
+	lightTheme.Name:   lightTheme,
+	asciiTheme.Name:   asciiTheme,
+}
+
+// themeNames returns the registry's keys in sorted order.
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveTheme picks the active theme for this run: an explicit --theme
+// flag wins, then GDU_VIEW_THEME, then an ascii fallback for terminals
+// that can't render color, then defaultTheme.
+func resolveTheme(flagTheme string) Theme {
+	name := flagTheme
+	if name == "" {
+		name = os.Getenv(themeEnvVar)
+	}
+	if name == "" {
+		if termenv.ColorProfile() == termenv.Ascii {
+			name = asciiTheme.Name
+		}
+	}
+	if name == "" {
+		return defaultTheme
+	}
+	if t, ok := themes[name]; ok {
+		return t
+	}
+	fmt.Fprintf(os.Stderr, "Unknown theme %q, falling back to %q (available: %s)\n",
+		name, defaultTheme.Name, strings.Join(themeNames(), ", "))
+	return defaultTheme
+}
+
+// PrintThemePreviews renders every registered theme as a sample
+// header/stats-bar/row/bar-chart preview to w, so a user can pick one
+// without editing config and restarting.
+func PrintThemePreviews(w io.Writer) {
+	for _, name := range themeNames() {
+		fmt.Fprintln(w, renderThemePreview(themes[name]))
+		fmt.Fprintln(w)
+	}
+}
+
+// renderThemePreview renders a single theme's sample widgets using t
+// directly, independent of the currently active theme.
+func renderThemePreview(t Theme) string {
+	var b strings.Builder
+
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.TextBright).
+		Background(t.Accent).
+		Padding(0, 2).
+		Render(fmt.Sprintf(" %s ", strings.ToUpper(t.Name)))
+	b.WriteString(header)
+	b.WriteString("\n")
+
+	statLabel := lipgloss.NewStyle().Foreground(t.TextDim)
+	statValue := lipgloss.NewStyle().Foreground(t.TextBright).Bold(true)
+	b.WriteString(statLabel.Render("Total: ") + statValue.Render("128.4 GB") +
+		"  |  " + statLabel.Render("Files: ") + statValue.Render("48.2K"))
+	b.WriteString("\n")
+
+	selected := lipgloss.NewStyle().Background(t.BgHighlight).Foreground(t.TextBright).Bold(true)
+	normal := lipgloss.NewStyle().Foreground(t.Text)
+	b.WriteString("  " + selected.Render(fmt.Sprintf("%-3s  %-20s  %10s", "[D]", "lab_notebooks/", "42.1 GB")))
+	b.WriteString("\n")
+	b.WriteString("  " + normal.Render(fmt.Sprintf("%-3s  %-20s  %10s", "[#]", "results.parquet", "6.3 GB")))
+	b.WriteString("\n")
+
+	b.WriteString("  ")
+	for _, category := range []string{"code", "data", "media", "archive", "doc", "config", "other", "dir"} {
+		color := t.FileTypes[category]
+		b.WriteString(lipgloss.NewStyle().Background(color).Render("  "))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// ExportThemesJSON writes every registered theme as JSON to w, one object
+// per theme, for external tooling (editors, docs) to consume.
+func ExportThemesJSON(w io.Writer) error {
+	names := themeNames()
+	export := make([]Theme, 0, len(names))
+	for _, name := range names {
+		export = append(export, themes[name])
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// treemapRect is a cell's position and size in character cells.
+type treemapRect struct {
+	X, Y, W, H float64
+}
+
+// treemapCell is one leaf of a squarified treemap layout.
+type treemapCell struct {
+	Node *Node
+	Rect treemapRect
+}
+
+// computeSquarifiedTreemap lays out nodes (assumed pre-sorted by Usage
+// descending) into the rectangle (x, y, w, h) using the squarified
+// treemap algorithm of Bruls, Huijse & van Wijk: rows are packed along
+// whichever side of the remaining rectangle is currently shorter, growing
+// each row only while doing so improves the worst aspect ratio among its
+// cells, then the row's area is sliced off and the remainder recurses.
+func computeSquarifiedTreemap(nodes []*Node, x, y, w, h float64) []treemapCell {
+	if len(nodes) == 0 || w <= 0 || h <= 0 {
+		return nil
+	}
+
+	var total int64
+	for _, n := range nodes {
+		total += n.Usage
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	scale := (w * h) / float64(total)
+
+	cells := make([]treemapCell, 0, len(nodes))
+	squarify(nodes, scale, x, y, w, h, &cells)
+	return cells
+}
+
+// squarify recursively lays out remaining into rect (x, y, w, h). scale
+// converts a node's Usage into area (character cells²).
+func squarify(remaining []*Node, scale, x, y, w, h float64, cells *[]treemapCell) {
+	if len(remaining) == 0 || w <= 0 || h <= 0 {
+		return
+	}
+
+	side := min64(w, h)
+
+	// Grow the row while doing so doesn't worsen the row's aspect ratio.
+	row := remaining[:1]
+	for len(row) < len(remaining) {
+		next := remaining[:len(row)+1]
+		if worstAspectRatio(next, scale, side) > worstAspectRatio(row, scale, side) {
+			break
+		}
+		row = next
+	}
+
+	var rowArea float64
+	for _, n := range row {
+		rowArea += float64(n.Usage) * scale
+	}
+
+	// Lay out the row along the rectangle's short side, then recurse on
+	// whatever's left after slicing the row's strip off.
+	if w <= h {
+		rowHeight := rowArea / w
+		if rowHeight > h {
+			rowHeight = h
+		}
+		cx := x
+		for _, n := range row {
+			cw := float64(n.Usage) * scale / rowHeight
+			*cells = append(*cells, treemapCell{Node: n, Rect: treemapRect{X: cx, Y: y, W: cw, H: rowHeight}})
+			cx += cw
+		}
+		squarify(remaining[len(row):], scale, x, y+rowHeight, w, h-rowHeight, cells)
+	} else {
+		rowWidth := rowArea / h
+		if rowWidth > w {
+			rowWidth = w
+		}
+		cy := y
+		for _, n := range row {
+			ch := float64(n.Usage) * scale / rowWidth
+			*cells = append(*cells, treemapCell{Node: n, Rect: treemapRect{X: x, Y: cy, W: rowWidth, H: ch}})
+			cy += ch
+		}
+		squarify(remaining[len(row):], scale, x+rowWidth, y, w-rowWidth, h, cells)
+	}
+}
+
+// worstAspectRatio returns the worst (largest) per-cell aspect ratio for a
+// candidate row of area-scaled nodes packed along side: the worst ratio
+// in a squarified row is always contributed by its largest or smallest
+// member, so only those two areas are needed.
+func worstAspectRatio(row []*Node, scale, side float64) float64 {
+	var s float64
+	minA, maxA := math.Inf(1), 0.0
+	for _, n := range row {
+		a := float64(n.Usage) * scale
+		s += a
+		if a < minA {
+			minA = a
+		}
+		if a > maxA {
+			maxA = a
+		}
+	}
+	if s == 0 || side == 0 {
+		return 0
+	}
+
+	return max64(side*side*maxA/(s*s), (s*s)/(side*side*minA))
+}
+
+func min64(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// treemapWidth and treemapHeight are the character-cell dimensions the
+// treemap is laid out in, derived from the terminal size.
+func (m model) treemapWidth() float64 {
+	return float64(max(20, min(m.width-4, 120)))
+}
+
+func (m model) treemapHeight() float64 {
+	return float64(max(5, m.visibleRows()))
+}
+
+// currentTreemapCells computes the treemap layout for m.children, sorted
+// by usage descending as the squarify algorithm requires. Both rendering
+// and arrow-key navigation call this so they always agree on geometry.
+func (m model) currentTreemapCells() []treemapCell {
+	nodes := make([]*Node, len(m.children))
+	copy(nodes, m.children)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Usage > nodes[j].Usage
+	})
+	return computeSquarifiedTreemap(nodes, 0, 0, m.treemapWidth(), m.treemapHeight())
+}
+
+type treemapDir int
+
+const (
+	treemapUp treemapDir = iota
+	treemapDown
+	treemapLeft
+	treemapRight
+)
+
+// treemapMove returns the index into the cells currentTreemapCells lays
+// out of the cell nearest to the current selection in direction dir, or
+// the current cursor if there's no cell that way.
+func (m model) treemapMove(dir treemapDir) int {
+	cells := m.currentTreemapCells()
+	if len(cells) == 0 {
+		return m.cursor
+	}
+
+	curIdx := m.cursor
+	if curIdx < 0 || curIdx >= len(cells) {
+		return 0
+	}
+	cur := cells[curIdx]
+	cx, cy := cur.Rect.X+cur.Rect.W/2, cur.Rect.Y+cur.Rect.H/2
+
+	best := -1
+	bestDist := 0.0
+	for i, c := range cells {
+		if i == curIdx {
+			continue
+		}
+		ox, oy := c.Rect.X+c.Rect.W/2, c.Rect.Y+c.Rect.H/2
+		dx, dy := ox-cx, oy-cy
+
+		switch dir {
+		case treemapUp:
+			if dy >= -0.01 {
+				continue
+			}
+		case treemapDown:
+			if dy <= 0.01 {
+				continue
+			}
+		case treemapLeft:
+			if dx >= -0.01 {
+				continue
+			}
+		case treemapRight:
+			if dx <= 0.01 {
+				continue
+			}
+		}
+
+		dist := dx*dx + dy*dy
+		if best == -1 || dist < bestDist {
+			best = i
+			bestDist = dist
+		}
+	}
+
+	if best == -1 {
+		return curIdx
+	}
+	return best
+}
+
+// renderTreemap renders m.children as a squarified treemap: each leaf is a
+// block of spaces colored by its FileType, with the name/size overlaid
+// when the cell is large enough to hold it.
+func (m model) renderTreemap() string {
+	cells := m.currentTreemapCells()
+	w, h := int(m.treemapWidth()), int(m.treemapHeight())
+
+	grid := make([][]string, h)
+	for r := range grid {
+		grid[r] = make([]string, w)
+		for c := range grid[r] {
+			grid[r][c] = lipgloss.NewStyle().Background(colorBgAlt).Render(" ")
+		}
+	}
+
+	for i, cell := range cells {
+		color := fileTypeColors[cell.Node.FileType]
+		if color == "" {
+			color = colorTextDim
+		}
+
+		style := lipgloss.NewStyle().Background(color).Foreground(colorBg)
+		if i == m.cursor {
+			style = style.Bold(true).Foreground(colorTextBright)
+		}
+
+		x0, y0 := int(cell.Rect.X), int(cell.Rect.Y)
+		x1, y1 := int(cell.Rect.X+cell.Rect.W), int(cell.Rect.Y+cell.Rect.H)
+		if x1 > w {
+			x1 = w
+		}
+		if y1 > h {
+			y1 = h
+		}
+
+		label := ""
+		cw := x1 - x0
+		if cw >= 4 && y1-y0 >= 1 {
+			text := truncate(cell.Node.Name, cw-2)
+			if y1-y0 >= 2 {
+				text = truncate(text+" "+humanize(cell.Node.Usage), cw-2)
+			}
+			label = " " + text
+		}
+		labelRunes := []rune(label)
+
+		for row := y0; row < y1; row++ {
+			for col := x0; col < x1; col++ {
+				if row < 0 || row >= h || col < 0 || col >= w {
+					continue
+				}
+				ch := " "
+				if row == y0 && col-x0 < len(labelRunes) {
+					ch = string(labelRunes[col-x0])
+				}
+				grid[row][col] = style.Render(ch)
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range grid {
+		for _, cell := range row {
+			b.WriteString(cell)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -53,8 +53,16 @@ func (m model) renderBreadcrumb() string {
 
 // renderStatsBar renders the statistics bar below the header.
 func (m model) renderStatsBar() string {
+	if m.viewMode == ViewDiff {
+		return m.renderDiffStatsBar()
+	}
+	if m.viewMode == ViewCart {
+		return m.renderCartStatsBar()
+	}
+
 	currentSize := m.currentUsage
 	currentItems := len(m.children)
+	cacheStats := m.cache.Stats()
 
 	stats := []string{
 		statLabelStyle.Render("Total: ") + statValueStyle.Render(humanize(m.stats.TotalUsage)),
@@ -62,6 +70,62 @@ func (m model) renderStatsBar() string {
 		statLabelStyle.Render("Dirs: ") + statValueStyle.Render(formatNumber(int(m.stats.TotalDirs))),
 		statLabelStyle.Render("Current: ") + statValueStyle.Render(humanize(currentSize)),
 		statLabelStyle.Render("Items: ") + statValueStyle.Render(formatNumber(currentItems)),
+		statLabelStyle.Render("Cache: ") + statValueStyle.Render(fmt.Sprintf("%d/%d/%d",
+			cacheStats.Hits, cacheStats.Misses, cacheStats.Prefetches)),
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(colorTextDim).
+		Render("<- ") + strings.Join(stats, "  |  ") + lipgloss.NewStyle().
+		Foreground(colorTextDim).
+		Render(" ->")
+}
+
+// renderCartStatsBar renders the stats bar for ViewCart: how many entries
+// are marked and the total bytes reclaimable if they were all deleted.
+func (m model) renderCartStatsBar() string {
+	stats := []string{
+		statLabelStyle.Render("Marked: ") + statValueStyle.Render(formatNumber(len(m.cart))),
+		statLabelStyle.Render("Reclaimable: ") + statValueStyle.Render(humanize(m.cartTotal())),
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(colorTextDim).
+		Render("<- ") + strings.Join(stats, "  |  ") + lipgloss.NewStyle().
+		Foreground(colorTextDim).
+		Render(" ->")
+}
+
+// renderDiffStatsBar renders the stats bar for ViewDiff: net usage and item
+// count change across the current directory's children, plus how many
+// entries were added/removed since the baseline.
+func (m model) renderDiffStatsBar() string {
+	var netUsage, netItems int64
+	var added, removed int
+
+	for _, n := range m.diffChildren {
+		netUsage += n.DeltaUsage
+		netItems += n.DeltaItems
+		switch n.Status {
+		case DiffAdded:
+			added++
+		case DiffRemoved:
+			removed++
+		}
+	}
+
+	netStr := humanize(netUsage)
+	if netUsage > 0 {
+		netStr = "+" + netStr
+	} else if netUsage < 0 {
+		netStr = "-" + humanize(-netUsage)
+	}
+
+	stats := []string{
+		statLabelStyle.Render("Net: ") + statValueStyle.Render(netStr),
+		statLabelStyle.Render("Net items: ") + statValueStyle.Render(fmt.Sprintf("%+d", netItems)),
+		statLabelStyle.Render("Added: ") + statValueStyle.Render(formatNumber(added)),
+		statLabelStyle.Render("Removed: ") + statValueStyle.Render(formatNumber(removed)),
 	}
 
 	return lipgloss.NewStyle().
@@ -87,6 +151,12 @@ func (m model) renderContent() string {
 		return m.renderTypesView()
 	case ViewLargest:
 		return m.renderLargestView()
+	case ViewDiff:
+		return m.renderDiffView()
+	case ViewTreemap:
+		return m.renderTreemap()
+	case ViewCart:
+		return m.renderCartView()
 	}
 	return ""
 }
@@ -94,21 +164,25 @@ func (m model) renderContent() string {
 // renderTreeView renders the tree navigation view.
 func (m model) renderTreeView() string {
 	list := m.children
+	matches := [][]int(nil)
 	if m.filtered != nil {
 		list = m.filtered
+		matches = m.matches
 	}
 
-	return m.renderNodeList(list, m.currentUsage)
+	return m.renderNodeList(list, matches, m.currentUsage)
 }
 
 // renderFlatView renders the flat file list view.
 func (m model) renderFlatView() string {
 	list := m.flatList
+	matches := [][]int(nil)
 	if m.filtered != nil {
 		list = m.filtered
+		matches = m.matches
 	}
 
-	return m.renderNodeList(list, m.stats.TotalUsage)
+	return m.renderNodeList(list, matches, m.stats.TotalUsage)
 }
 
 // renderLargestView renders the largest files view.
@@ -122,12 +196,163 @@ func (m model) renderLargestView() string {
 	b.WriteString(header)
 	b.WriteString("\n\n")
 
-	b.WriteString(m.renderNodeList(m.largestFiles, m.stats.TotalUsage))
+	list := m.largestFiles
+	matches := [][]int(nil)
+	if m.filtered != nil {
+		list = m.filtered
+		matches = m.matches
+	}
+
+	b.WriteString(m.renderNodeList(list, matches, m.stats.TotalUsage))
+	return b.String()
+}
+
+// renderCartView renders everything marked for deletion, with the rolled-up
+// reclaimable total shown in the stats bar (see renderCartStatsBar).
+func (m model) renderCartView() string {
+	var b strings.Builder
+
+	header := lipgloss.NewStyle().
+		Foreground(colorDanger).
+		Bold(true).
+		Render(fmt.Sprintf(">> CART  (%d marked, %s reclaimable)", len(m.cart), humanize(m.cartTotal())))
+	b.WriteString(header)
+	b.WriteString("\n\n")
+
+	list := m.cartList()
+	if len(list) == 0 {
+		b.WriteString(normalStyle.Render("  Nothing marked. Press space on an entry in another view to add it."))
+		return b.String()
+	}
+
+	b.WriteString(m.renderNodeList(list, nil, m.cartTotal()))
 	return b.String()
 }
 
-// renderNodeList renders a list of nodes with size bars.
-func (m model) renderNodeList(list []*Node, parentSize int64) string {
+// renderDiffView renders the current directory's children compared against
+// the --baseline snapshot: a diverging bar centered on zero, green growing
+// right for gains and red growing left for losses, with a +/-/~ status icon.
+func (m model) renderDiffView() string {
+	var b strings.Builder
+
+	header := lipgloss.NewStyle().
+		Foreground(colorPurple).
+		Bold(true).
+		Render(">> DIFF vs BASELINE  (" + m.diffSortMode.String() + ")")
+	b.WriteString(header)
+	b.WriteString("\n\n")
+
+	list := m.diffChildren
+
+	colHeader := lipgloss.NewStyle().
+		Foreground(colorTextDim).
+		Bold(true).
+		Render(fmt.Sprintf("  %-3s  %-40s  %10s  %-21s",
+			"", "NAME", "USAGE", "DELTA"))
+	b.WriteString(colHeader)
+	b.WriteString("\n")
+
+	separator := lipgloss.NewStyle().
+		Foreground(colorBorder).
+		Render(strings.Repeat("-", min(m.width-4, 90)))
+	b.WriteString(separator)
+	b.WriteString("\n")
+
+	visible := m.visibleRows()
+	start := m.offset
+	end := min(start+visible, len(list))
+
+	// Largest delta magnitude across all rows (not just those on screen),
+	// to scale the diverging bar; falls back to 1 to avoid dividing by zero
+	// when all are unchanged.
+	var maxDelta int64 = 1
+	for _, n := range list {
+		if d := abs64(n.DeltaUsage); d > maxDelta {
+			maxDelta = d
+		}
+	}
+
+	for i := start; i < end; i++ {
+		n := list[i]
+		isSelected := i == m.cursor
+
+		name := truncate(n.Name, 40)
+		if n.IsDir {
+			name = name + "/"
+		}
+		namePad := strings.Repeat(" ", max(0, 40-len([]rune(name))))
+
+		var deltaStr string
+		if n.DeltaUsage < 0 {
+			deltaStr = "-" + humanize(-n.DeltaUsage)
+		} else if n.DeltaUsage > 0 {
+			deltaStr = "+" + humanize(n.DeltaUsage)
+		} else {
+			deltaStr = humanize(0)
+		}
+
+		bar := m.renderDivergingBar(n.DeltaUsage, maxDelta, 20)
+
+		line := fmt.Sprintf("%-3s  %s%s  %10s  %-8s %s",
+			n.Status.Icon(), name, namePad, humanize(n.Usage), deltaStr, bar)
+
+		var style lipgloss.Style
+		if isSelected {
+			style = selectedStyle
+		} else {
+			style = normalStyle
+		}
+
+		cursor := "  "
+		if isSelected {
+			cursor = lipgloss.NewStyle().Foreground(colorAccent).Bold(true).Render("> ")
+		}
+
+		b.WriteString(cursor)
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	if len(list) > visible {
+		scrollInfo := lipgloss.NewStyle().
+			Foreground(colorTextDim).
+			Render(fmt.Sprintf("\n  [%d-%d of %d]", start+1, end, len(list)))
+		b.WriteString(scrollInfo)
+	}
+
+	return b.String()
+}
+
+// renderDivergingBar renders a bar centered on zero: green growing right for
+// a positive delta, red growing left for a negative one, scaled so that a
+// delta of magnitude max fills one half of width.
+func (m model) renderDivergingBar(delta, max int64, width int) string {
+	half := width / 2
+	filled := int(float64(abs64(delta)) / float64(max) * float64(half))
+	if filled > half {
+		filled = half
+	}
+
+	left := strings.Repeat(barEmpty, half)
+	right := strings.Repeat(barEmpty, half)
+
+	if delta > 0 {
+		right = strings.Repeat(barFull, filled) + strings.Repeat(barEmpty, half-filled)
+	} else if delta < 0 {
+		left = strings.Repeat(barEmpty, half-filled) + strings.Repeat(barFull, filled)
+	}
+
+	leftStyle := lipgloss.NewStyle().Foreground(colorDanger).Render(left)
+	rightStyle := lipgloss.NewStyle().Foreground(colorSuccess).Render(right)
+	mid := lipgloss.NewStyle().Foreground(colorBorder).Render("|")
+
+	return leftStyle + mid + rightStyle
+}
+
+// renderNodeList renders a list of nodes with size bars. matches, when
+// non-nil, holds the fuzzy-matched rune indexes for list[i] into
+// searchKey(list[i]) and is used to highlight matched runes in the name.
+func (m model) renderNodeList(list []*Node, matches [][]int, parentSize int64) string {
 	var b strings.Builder
 
 	visible := m.visibleRows()
@@ -166,9 +391,13 @@ func (m model) renderNodeList(list []*Node, parentSize int64) string {
 		if node.IsDir {
 			name = name + "/"
 		}
+		namePad := strings.Repeat(" ", max(0, 40-len([]rune(name))))
+		if i < len(matches) {
+			name = highlightMatches(name, nameRelativeMatches(m.viewMode, node, matches[i]))
+		}
 
-		line := fmt.Sprintf("%-3s  %-40s  %10s  %5.1f%%  %s",
-			icon, name, humanize(node.Usage), pct, bar)
+		line := fmt.Sprintf("%-3s  %s%s  %10s  %5.1f%%  %s",
+			icon, name, namePad, humanize(node.Usage), pct, bar)
 
 		var style lipgloss.Style
 		if isSelected {
@@ -177,9 +406,18 @@ func (m model) renderNodeList(list []*Node, parentSize int64) string {
 			style = normalStyle
 		}
 
-		cursor := "  "
-		if isSelected {
-			cursor = lipgloss.NewStyle().Foreground(colorAccent).Bold(true).Render("> ")
+		_, marked := m.cart[node.FullPath]
+
+		var cursor string
+		switch {
+		case isSelected && marked:
+			cursor = lipgloss.NewStyle().Background(colorAccent).Foreground(colorBg).Bold(true).Render("[X]")
+		case marked:
+			cursor = lipgloss.NewStyle().Background(colorAccent).Foreground(colorBg).Render("[x]")
+		case isSelected:
+			cursor = lipgloss.NewStyle().Foreground(colorAccent).Bold(true).Render(" > ")
+		default:
+			cursor = "   "
 		}
 
 		b.WriteString(cursor)
@@ -257,6 +495,38 @@ func (m model) renderTypesView() string {
 	b.WriteString("\n")
 	b.WriteString(m.renderMiniChart())
 
+	if m.deepScan {
+		b.WriteString("\n\n")
+		b.WriteString(m.renderMismatchReport())
+	}
+
+	return b.String()
+}
+
+// renderMismatchReport lists files --deep-scan found whose real content
+// disagrees with their extension, e.g. "file.csv is actually application/zip".
+func (m model) renderMismatchReport() string {
+	var b strings.Builder
+
+	header := lipgloss.NewStyle().
+		Foreground(colorWarning).
+		Bold(true).
+		Render(fmt.Sprintf(">> DEEP SCAN: %d MISMATCH(ES)", len(m.mismatches)))
+	b.WriteString(header)
+	b.WriteString("\n")
+
+	if len(m.mismatches) == 0 {
+		b.WriteString(normalStyle.Render("  Nothing sniffed so far disagrees with its extension."))
+		return b.String()
+	}
+
+	for _, mm := range m.mismatches {
+		line := fmt.Sprintf("  %s is actually %s (extension suggests %s)",
+			mm.Path, mm.MIME, mm.Extension)
+		b.WriteString(normalStyle.Render(line))
+		b.WriteString("\n")
+	}
+
 	return b.String()
 }
 
@@ -342,8 +612,14 @@ func (m model) renderBarColored(pct float64, width int, color lipgloss.Color) st
 	return bar + empty
 }
 
-// getIcon returns an icon for a node based on its type.
+// getIcon returns an icon for a node based on its type: a Nerd Font glyph
+// when --icons resolved to enabled, otherwise the plain [X] bracket codes
+// this tool has always used.
 func (m model) getIcon(node *Node) string {
+	if iconsEnabled {
+		return string(IconFor(node))
+	}
+
 	if node.IsDir {
 		return "[D]"
 	}
@@ -368,6 +644,10 @@ func (m model) getIcon(node *Node) string {
 
 // renderHelpBar renders the help/shortcuts bar.
 func (m model) renderHelpBar() string {
+	if m.statusMsg != "" {
+		return lipgloss.NewStyle().Foreground(colorSuccess).Bold(true).Render(">> " + m.statusMsg)
+	}
+
 	if m.showHelp {
 		return m.help.View(keys)
 	}
@@ -388,6 +668,30 @@ func (m model) renderHelpBar() string {
 
 // Helper functions
 
+// highlightMatches renders s with the runes at the given (rune) indexes
+// styled as matched, and all others in normalStyle.
+func highlightMatches(s string, indexes []int) string {
+	if len(indexes) == 0 {
+		return normalStyle.Render(s)
+	}
+
+	matchStyle := lipgloss.NewStyle().Foreground(colorAccentBright).Bold(true)
+	matched := make(map[int]bool, len(indexes))
+	for _, idx := range indexes {
+		matched[idx] = true
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(s) {
+		if matched[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteString(normalStyle.Render(string(r)))
+		}
+	}
+	return b.String()
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) > maxLen {
 		return s[:maxLen-3] + "..."